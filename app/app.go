@@ -5,17 +5,23 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/sjmudd/anonymiser"
 	"github.com/sjmudd/ps-top/config"
 	"github.com/sjmudd/ps-top/connector"
+	"github.com/sjmudd/ps-top/dashboard"
 	"github.com/sjmudd/ps-top/display"
 	"github.com/sjmudd/ps-top/event"
+	"github.com/sjmudd/ps-top/export"
 	"github.com/sjmudd/ps-top/global"
 	"github.com/sjmudd/ps-top/log"
 	"github.com/sjmudd/ps-top/model/filter"
 	"github.com/sjmudd/ps-top/pstable"
+	"github.com/sjmudd/ps-top/record"
 	"github.com/sjmudd/ps-top/setupinstruments"
 	"github.com/sjmudd/ps-top/utils"
 	"github.com/sjmudd/ps-top/view"
@@ -25,17 +31,35 @@ import (
 // Settings holds the application configuration settings from the command line.
 type Settings struct {
 	Anonymise bool                   // Do we want to anonymise data shown?
+	Batch     bool                   // write plain text output to stdout rather than using the screen
+	Count     int                    // number of collections to show in batch mode (0 = no limit)
+	Format    export.Format          // format of the batch mode output
 	Filter    *filter.DatabaseFilter // optional names of databases to filter on
 	Interval  int                    // default interval to poll information
+	Layout    dashboard.Layout       // how the rows of the servers are shown in the dashboard
+	Listen    string                 // address to serve metrics on (exporter mode) if not empty
+	Record    string                 // file to record the collected data to if not empty
+	Replay    string                 // file to replay previously recorded data from if not empty
+	Servers   []string               // targets of the servers to show together in a dashboard if not empty
 	ViewName  string                 // name of the view to start with
 }
 
 // App holds the data needed by an application
 type App struct {
+	batch            bool                               // are we writing plain text output to stdout?
+	count            int                                // number of collections to show in batch mode (0 = no limit)
 	config           *config.Config                     // some config needed by the display
-	db               *sql.DB                            // connection to MySQL
-	display          *display.Display                   // display displays the information to the screen
+	dashboard        *dashboard.Dashboard               // shows several servers together (nil unless using a dashboard)
+	db               *sql.DB                            // connection to MySQL (nil if using a dashboard)
+	display          *display.Display                   // display displays the information to the screen (nil in batch mode)
+	exporter         export.Writer                      // writes machine readable batch output (nil for text output)
 	finished         bool                               // has the app finished?
+	listener         net.Listener                       // listener for serving metrics (exporter mode only)
+	mu               sync.Mutex                         // protects collected data when serving metrics
+	recorder         *record.Recorder                   // records the collected data (nil if not recording)
+	replayer         *record.Replayer                   // provides recorded data instead of the database (nil if not replaying)
+	servers          []*server                          // servers shown in the dashboard (nil unless using a dashboard)
+	status           *global.Status                     // global status (fixed values when replaying)
 	collector        *DBCollector                       // owns all tablers and collection logic
 	signalHandler    *SignalHandler                     // handles signals
 	waiter           *wait.Waiter                       // for handling waits between collecting metrics
@@ -67,71 +91,182 @@ func performanceSchemaEnabled(variables *global.Variables) error {
 // NewApp sets up the application given various parameters returning a possible if initialisation fails.
 func NewApp(
 	connectorFlags connector.Config,
-	settings Settings) (*App, error) {
+	settings Settings) (_ *App, err error) {
 	log.Println("app.NewApp()")
 	app := new(App)
+	defer func() {
+		// stop listening if a later step fails
+		if err != nil && app.listener != nil {
+			_ = app.listener.Close()
+		}
+	}()
 
 	anonymiser.Enable(settings.Anonymise)
-	conn, err := connector.NewConnector(connectorFlags)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
-	}
-	app.db = conn.DB
 
-	status := global.NewStatus(app.db)
-	variables := global.NewVariables(app.db)
+	var variables *global.Variables
+	var viewDB *sql.DB // connection used to check which views can be selected
+	if settings.Replay != "" {
+		// replaying needs no database: the server's variables and
+		// status come from the recording.
+		replayer, err := record.NewReplayer(settings.Replay, recordedModels())
+		if err != nil {
+			return nil, err
+		}
+		app.replayer = replayer
+		app.status = global.NewFixedStatus()
+		variables = global.NewFixedVariables(replayer.Header().Variables)
+	} else if len(settings.Servers) > 0 {
+		// each server has its own connection, the first server which
+		// can be used provides the views which can be selected.
+		first, err := app.connectServers(connectorFlags, settings)
+		if err != nil {
+			return nil, err
+		}
+		app.status = first.status
+		variables = first.config.Variables()
+		viewDB = first.db
+	} else {
+		conn, err := connector.NewConnector(connectorFlags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		app.db = conn.DB
+		viewDB = app.db
 
-	// Prior to setting up screen check that performance_schema is enabled.
-	// On MariaDB this is not the default setting so it will confuse people.
-	if err := performanceSchemaEnabled(variables); err != nil {
-		return nil, err
+		app.status = global.NewStatus(app.db)
+		variables = global.NewVariables(app.db)
+
+		// Prior to setting up screen check that performance_schema is enabled.
+		// On MariaDB this is not the default setting so it will confuse people.
+		if err := performanceSchemaEnabled(variables); err != nil {
+			return nil, err
+		}
 	}
 
-	app.config = config.NewConfig(status, variables, settings.Filter, true)
-	app.display = display.NewDisplay(app.config)
+	app.config = config.NewConfig(app.status, variables, settings.Filter, true)
+	var displayConfig display.Config = app.config
+	if app.dashboard != nil {
+		displayConfig = dashboardConfig{Config: app.config, app: app}
+	}
+	app.batch = settings.Batch
+	app.count = settings.Count
 	app.finished = false
-	app.display.Clear()
 
-	app.setupInstruments = setupinstruments.NewSetupInstruments(app.db)
-	app.setupInstruments.EnableMonitoring()
+	// In exporter mode metrics are served over HTTP and we do not need a terminal.
+	// In batch mode we write plain text to stdout and do not need a terminal.
+	var displayer view.Displayer
+	if settings.Listen != "" {
+		listener, err := net.Listen("tcp", settings.Listen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", settings.Listen, err)
+		}
+		app.listener = listener
+		// counters must only increase so always provide absolute values
+		app.config.SetWantRelativeStats(false)
+	} else if app.batch {
+		displayer = display.NewBatch(displayConfig, os.Stdout)
+		switch settings.Format {
+		case export.FormatJSON:
+			app.exporter = export.NewJSON(os.Stdout)
+		case export.FormatCSV:
+			app.exporter = export.NewCSV(os.Stdout)
+		}
+	} else {
+		app.display = display.NewDisplay(displayConfig)
+		app.display.Clear()
+		displayer = app.display
+	}
+
+	if app.db != nil {
+		app.setupInstruments = setupinstruments.NewSetupInstruments(app.db)
+		app.setupInstruments.EnableMonitoring()
+	}
 
 	app.waiter = wait.NewWaiter()
 	app.waiter.SetWaitInterval(time.Second * time.Duration(settings.Interval))
 
 	// Create DBCollector to manage all data collection (replaces individual tabler fields)
 	log.Println("app.NewApp: Setting up models via DBCollector")
-	app.collector = NewDBCollector(app.config, app.db)
+	if app.dashboard != nil {
+		app.collector = app.newDashboardCollector()
+	} else {
+		app.collector = NewDBCollector(app.config, app.db)
+	}
 
 	// Create signal handler
 	app.signalHandler = NewSignalHandler()
 
 	// Setup view system using ViewManager
+	var v view.View
 	var viewErr error
-	v, viewErr := view.SetupAndValidate(settings.ViewName, app.db) // if empty will use the default
+	if app.replayer != nil {
+		v, viewErr = view.SetupWithNames(settings.ViewName, app.replayer.Header().Views)
+	} else {
+		v, viewErr = view.SetupAndValidate(settings.ViewName, viewDB) // if empty will use the default
+	}
 	if viewErr != nil {
 		return nil, fmt.Errorf("app.NewApp: %w", viewErr)
 	}
 
 	// Build tabler mapping for ViewManager
 	tablers := map[view.Code]pstable.Tabler{
-		view.ViewLatency: app.collector.tableIoLatency,
-		view.ViewOps:     app.collector.tableIoOps,
-		view.ViewIO:      app.collector.fileInfoLatency,
-		view.ViewLocks:   app.collector.tableLockLatency,
-		view.ViewUsers:   app.collector.userLatency,
-		view.ViewMutex:   app.collector.mutexLatency,
-		view.ViewStages:  app.collector.stagesLatency,
-		view.ViewMemory:  app.collector.memoryUsage,
+		view.ViewLatency:         app.collector.tableIoLatency,
+		view.ViewOps:             app.collector.tableIoOps,
+		view.ViewIO:              app.collector.fileInfoLatency,
+		view.ViewLocks:           app.collector.tableLockLatency,
+		view.ViewUsers:           app.collector.userLatency,
+		view.ViewMutex:           app.collector.mutexLatency,
+		view.ViewStages:          app.collector.stagesLatency,
+		view.ViewMemory:          app.collector.memoryUsage,
+		view.ViewDigest:          app.collector.statementDigest,
+		view.ViewRunning:         app.collector.runningStatements,
+		view.ViewLockWaits:       app.collector.lockWaits,
+		view.ViewMetadataLocks:   app.collector.metadataLocks,
+		view.ViewReplication:     app.collector.replication,
+		view.ViewIndexUsage:      app.collector.indexUsage,
+		view.ViewUnusedIndexes:   app.collector.unusedIndexes,
+		view.ViewAccountActivity: app.collector.accountActivity,
+		view.ViewHostActivity:    app.collector.hostActivity,
+		view.ViewGlobalStatus:    app.collector.globalStatus,
+		view.ViewTransactions:    app.collector.transactions,
+		view.ViewHistogram:       app.collector.statementHistogram,
+		view.ViewStageProgress:   app.collector.stageProgress,
+		view.ViewFileIoCategory:  app.collector.fileIoCategory,
+		view.ViewFileIoEvent:     app.collector.fileIoEvent,
+		view.ViewSocketIoType:    app.collector.socketIoType,
+		view.ViewSocketIoAddress: app.collector.socketIoAddress,
+		view.ViewMemoryByOwner:   app.collector.memoryByOwner,
 	}
 
 	// Create ViewManager, passing collector as the TablerUpdater
-	app.viewManager = view.NewManager(v, tablers, app.display, app.collector)
+	app.viewManager = view.NewManager(v, tablers, displayer, app.collector)
+
+	if app.replayer != nil {
+		if err := app.startReplay(); err != nil {
+			return nil, err
+		}
+		log.Println("app.NewApp() finishes")
+		return app, nil
+	}
 
 	// Initial collection and reset to establish baseline
 	log.Println("app.NewApp: Initial collection and reset")
 	app.collector.CollectAll()
 	app.collector.ResetAll()
 
+	if settings.Record != "" {
+		recorder, err := record.NewRecorder(settings.Record, record.Header{
+			Started:   time.Now(),
+			Variables: variables.All(),
+			Views:     app.viewManager.Names(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		app.recorder = recorder
+		app.recordFrame()
+	}
+
 	log.Println("app.NewApp() finishes")
 	return app, nil
 }
@@ -141,28 +276,60 @@ func (app *App) Collect() {
 	log.Println("app.Collect()")
 	start := time.Now()
 
-	app.collector.Collect()
+	if app.recorder != nil {
+		// all models are recorded, not just the one being viewed
+		app.collector.CollectAll()
+		app.recordFrame()
+	} else {
+		app.collector.Collect()
+		if app.viewManager.Detail() {
+			// the detail includes rows from other views
+			for _, tabler := range app.viewManager.RelatedTablers() {
+				tabler.Collect()
+			}
+		}
+	}
 	app.waiter.CollectedNow()
 	log.Println("app.Collect() took", time.Since(start))
 }
 
 // Display shows the output appropriate to the corresponding view and device
 func (app *App) Display() {
+	if app.dashboard != nil && app.display != nil {
+		app.dashboard.SetRows(app.display.TableRows())
+	}
 	app.viewManager.Display()
 }
 
 // Cleanup prepares the application prior to shutting down
 func (app *App) Cleanup() {
-	app.display.Fini()
+	if app.display != nil {
+		app.display.Fini()
+	}
+	if app.recorder != nil {
+		if err := app.recorder.Close(); err != nil {
+			log.Printf("App.Cleanup: failed to close recording: %v", err)
+		}
+	}
 	if app.db != nil {
 		app.setupInstruments.RestoreConfiguration()
 		_ = app.db.Close()
 	}
+	app.closeServers()
 	log.Println("App.Cleanup completed")
 }
 
 // Run runs the application in a loop until we're ready to finish
 func (app *App) Run() {
+	if app.listener != nil {
+		app.runExporter()
+		return
+	}
+	if app.batch {
+		app.runBatch()
+		return
+	}
+
 	defer app.Cleanup()
 
 	log.Println("app.Run()")
@@ -184,6 +351,79 @@ func (app *App) Run() {
 	}
 }
 
+// runBatch collects and writes the current view to stdout every interval
+// until the requested number of collections have been made or we are
+// asked to stop. There is no keyboard input in this mode.
+func (app *App) runBatch() {
+	defer app.Cleanup()
+
+	log.Printf("app.runBatch(): count: %d", app.count)
+
+	if app.replayer != nil {
+		app.runBatchReplay()
+		return
+	}
+
+	// The baseline was collected in NewApp() so wait a full interval
+	// before showing the first set of values.
+	app.waiter.CollectedNow()
+
+	for shown := 0; !app.finished && (app.count == 0 || shown < app.count); {
+		select {
+		case sig := <-app.signalHandler.Channel():
+			log.Println("Caught signal: ", sig)
+			app.finished = true
+		case <-app.waiter.WaitUntilNextPeriod():
+			app.Collect()
+			app.output()
+			shown++
+		}
+	}
+}
+
+// output writes the current view in batch mode either as text or
+// using the configured exporter.
+func (app *App) output() {
+	if app.exporter == nil {
+		app.Display()
+		return
+	}
+
+	data, ok := app.viewManager.CurrentTabler().(export.Data)
+	if !ok {
+		log.Printf("app.output: view %s does not support exporting its data", app.viewManager.Name())
+		return
+	}
+	if err := app.exporter.Write(app.viewManager.Name(), data); err != nil {
+		log.Printf("app.output: failed to write view %s: %v", app.viewManager.Name(), err)
+	}
+}
+
+// moveSelection moves the selected row of the current view as requested
+// and updates the display
+func (app *App) moveSelection(eventType event.Type) {
+	selector, ok := app.viewManager.CurrentTabler().(pstable.Selector)
+	if !ok {
+		return
+	}
+
+	switch eventType {
+	case event.EventRowUp:
+		selector.MoveSelection(-1)
+	case event.EventRowDown:
+		selector.MoveSelection(1)
+	case event.EventPageUp:
+		selector.MoveSelection(-app.display.TableRows())
+	case event.EventPageDown:
+		selector.MoveSelection(app.display.TableRows())
+	case event.EventFirstRow:
+		selector.SelectFirst()
+	case event.EventLastRow:
+		selector.SelectLast()
+	}
+	app.Display()
+}
+
 // collectAndDisplay runs a collection and then updates the display.
 // Extracted to keep the Run loop concise.
 func (app *App) collectAndDisplay() {
@@ -215,11 +455,56 @@ func (app *App) handleInputEvent(inputEvent event.Event) bool {
 		app.viewManager.ClearDisplay()
 		app.Display()
 	case event.EventToggleWantRelative:
-		app.config.SetWantRelativeStats(!app.config.WantRelativeStats())
+		app.setWantRelativeStats(!app.config.WantRelativeStats())
 		app.Display()
 	case event.EventResetStatistics:
 		app.collector.ResetAll()
 		app.Display()
+	case event.EventSortNext:
+		if sorter, ok := app.viewManager.CurrentTabler().(pstable.Sorter); ok {
+			sorter.NextSortKey()
+			app.Display()
+		}
+	case event.EventSortReverse:
+		if sorter, ok := app.viewManager.CurrentTabler().(pstable.Sorter); ok {
+			sorter.ReverseSort()
+			app.Display()
+		}
+	case event.EventGroupNext:
+		if grouper, ok := app.viewManager.CurrentTabler().(pstable.Grouper); ok {
+			grouper.NextGrouping()
+			app.Display()
+		}
+	case event.EventFilter:
+		if filterer, ok := app.viewManager.CurrentTabler().(pstable.Filterer); ok {
+			filterer.SetFilter(inputEvent.Text)
+		}
+		app.Display()
+	case event.EventFilterClear:
+		if filterer, ok := app.viewManager.CurrentTabler().(pstable.Filterer); ok {
+			filterer.SetFilter("")
+			app.Display()
+		}
+	case event.EventDashboardLayout:
+		if app.dashboard != nil {
+			app.dashboard.ToggleLayout()
+			app.viewManager.ClearDisplay()
+			app.Display()
+		}
+	case event.EventDetail:
+		app.viewManager.ToggleDetail()
+		app.viewManager.ClearDisplay()
+		app.Display()
+	case event.EventRowUp, event.EventRowDown, event.EventPageUp, event.EventPageDown, event.EventFirstRow, event.EventLastRow:
+		app.moveSelection(inputEvent.Type)
+	case event.EventReplayNext:
+		if app.replayer != nil && app.replayer.Next() {
+			return app.showReplayFrame()
+		}
+	case event.EventReplayPrev:
+		if app.replayer != nil && app.replayer.Prev() {
+			return app.showReplayFrame()
+		}
 	case event.EventResizeScreen:
 		width, height := inputEvent.Width, inputEvent.Height
 		app.display.Resize(width, height)
@@ -13,17 +13,35 @@ import (
 // DBCollector owns all the Tabler instances and coordinates data collection.
 // It knows nothing about display, signals, or event loops - only database collection.
 type DBCollector struct {
-	config           *config.Config
-	db               *sql.DB
-	fileInfoLatency  pstable.Tabler
-	tableIoLatency   pstable.Tabler
-	tableIoOps       pstable.Tabler
-	tableLockLatency pstable.Tabler
-	mutexLatency     pstable.Tabler
-	stagesLatency    pstable.Tabler
-	memoryUsage      pstable.Tabler
-	userLatency      pstable.Tabler
-	currentTabler    pstable.Tabler
+	config             *config.Config
+	db                 *sql.DB
+	fileInfoLatency    pstable.Tabler
+	tableIoLatency     pstable.Tabler
+	tableIoOps         pstable.Tabler
+	tableLockLatency   pstable.Tabler
+	mutexLatency       pstable.Tabler
+	stagesLatency      pstable.Tabler
+	memoryUsage        pstable.Tabler
+	userLatency        pstable.Tabler
+	statementDigest    pstable.Tabler
+	runningStatements  pstable.Tabler
+	lockWaits          pstable.Tabler
+	metadataLocks      pstable.Tabler
+	replication        pstable.Tabler
+	indexUsage         pstable.Tabler
+	unusedIndexes      pstable.Tabler
+	accountActivity    pstable.Tabler
+	hostActivity       pstable.Tabler
+	globalStatus       pstable.Tabler
+	transactions       pstable.Tabler
+	statementHistogram pstable.Tabler
+	stageProgress      pstable.Tabler
+	fileIoCategory     pstable.Tabler
+	fileIoEvent        pstable.Tabler
+	socketIoType       pstable.Tabler
+	socketIoAddress    pstable.Tabler
+	memoryByOwner      pstable.Tabler
+	currentTabler      pstable.Tabler
 }
 
 // NewDBCollector creates and initializes all tablers.
@@ -44,6 +62,24 @@ func NewDBCollector(cfg *config.Config, db *sql.DB) *DBCollector {
 	dc.stagesLatency = pstable.NewTabler(pstable.StagesLatency, cfg, db)
 	dc.memoryUsage = pstable.NewTabler(pstable.MemoryUsage, cfg, db)
 	dc.userLatency = pstable.NewTabler(pstable.UserLatency, cfg, db)
+	dc.statementDigest = pstable.NewTabler(pstable.StatementDigest, cfg, db)
+	dc.runningStatements = pstable.NewTabler(pstable.RunningStatements, cfg, db)
+	dc.lockWaits = pstable.NewTabler(pstable.LockWaits, cfg, db)
+	dc.metadataLocks = pstable.NewTabler(pstable.MetadataLocks, cfg, db)
+	dc.replication = pstable.NewTabler(pstable.Replication, cfg, db)
+	dc.indexUsage = pstable.NewTabler(pstable.IndexUsage, cfg, db)
+	dc.unusedIndexes = pstable.NewTabler(pstable.UnusedIndexes, cfg, db)
+	dc.accountActivity = pstable.NewTabler(pstable.AccountActivity, cfg, db)
+	dc.hostActivity = pstable.NewTabler(pstable.HostActivity, cfg, db)
+	dc.globalStatus = pstable.NewTabler(pstable.GlobalStatus, cfg, db)
+	dc.transactions = pstable.NewTabler(pstable.Transactions, cfg, db)
+	dc.statementHistogram = pstable.NewTabler(pstable.StatementHistogram, cfg, db)
+	dc.stageProgress = pstable.NewTabler(pstable.StageProgress, cfg, db)
+	dc.fileIoCategory = pstable.NewTabler(pstable.FileIoCategory, cfg, db)
+	dc.fileIoEvent = pstable.NewTabler(pstable.FileIoEvent, cfg, db)
+	dc.socketIoType = pstable.NewTabler(pstable.SocketIoType, cfg, db)
+	dc.socketIoAddress = pstable.NewTabler(pstable.SocketIoAddress, cfg, db)
+	dc.memoryByOwner = pstable.NewTabler(pstable.MemoryByOwner, cfg, db)
 
 	return dc
 }
@@ -64,6 +100,24 @@ func (dc *DBCollector) CollectAll() {
 	dc.stagesLatency.Collect()
 	dc.mutexLatency.Collect()
 	dc.memoryUsage.Collect()
+	dc.statementDigest.Collect()
+	dc.runningStatements.Collect()
+	dc.lockWaits.Collect()
+	dc.metadataLocks.Collect()
+	dc.replication.Collect()
+	dc.indexUsage.Collect()
+	dc.unusedIndexes.Collect()
+	dc.accountActivity.Collect()
+	dc.hostActivity.Collect()
+	dc.globalStatus.Collect()
+	dc.transactions.Collect()
+	dc.statementHistogram.Collect()
+	dc.stageProgress.Collect()
+	dc.fileIoCategory.Collect()
+	dc.fileIoEvent.Collect()
+	dc.socketIoType.Collect()
+	dc.socketIoAddress.Collect()
+	dc.memoryByOwner.Collect()
 }
 
 // ResetAll resets statistics on all tablers.
@@ -76,6 +130,69 @@ func (dc *DBCollector) ResetAll() {
 	dc.stagesLatency.ResetStatistics()
 	dc.mutexLatency.ResetStatistics()
 	dc.memoryUsage.ResetStatistics()
+	dc.statementDigest.ResetStatistics()
+	dc.runningStatements.ResetStatistics()
+	dc.lockWaits.ResetStatistics()
+	dc.metadataLocks.ResetStatistics()
+	dc.replication.ResetStatistics()
+	dc.indexUsage.ResetStatistics()
+	dc.unusedIndexes.ResetStatistics()
+	dc.accountActivity.ResetStatistics()
+	dc.hostActivity.ResetStatistics()
+	dc.globalStatus.ResetStatistics()
+	dc.transactions.ResetStatistics()
+	dc.statementHistogram.ResetStatistics()
+	dc.stageProgress.ResetStatistics()
+	dc.fileIoCategory.ResetStatistics()
+	dc.fileIoEvent.ResetStatistics()
+	dc.socketIoType.ResetStatistics()
+	dc.socketIoAddress.ResetStatistics()
+	dc.memoryByOwner.ResetStatistics()
+}
+
+// MetricsTablers returns the tablers whose data is served as metrics.
+func (dc *DBCollector) MetricsTablers() []pstable.Tabler {
+	return []pstable.Tabler{
+		dc.tableIoLatency,
+		dc.fileInfoLatency,
+		dc.mutexLatency,
+		dc.stagesLatency,
+		dc.memoryUsage,
+		dc.userLatency,
+	}
+}
+
+// RecordedTablers returns the tablers whose raw data is recorded and
+// replayed, keyed by the name of their model. The table I/O views share
+// a model so only one of them is included.
+func (dc *DBCollector) RecordedTablers() map[string]pstable.Tabler {
+	return map[string]pstable.Tabler{
+		"file_io":             dc.fileInfoLatency,
+		"table_io":            dc.tableIoLatency,
+		"table_locks":         dc.tableLockLatency,
+		"users":               dc.userLatency,
+		"stages":              dc.stagesLatency,
+		"mutex":               dc.mutexLatency,
+		"memory":              dc.memoryUsage,
+		"statements":          dc.statementDigest,
+		"running":             dc.runningStatements,
+		"lock_waits":          dc.lockWaits,
+		"metadata_locks":      dc.metadataLocks,
+		"replication":         dc.replication,
+		"index_usage":         dc.indexUsage,
+		"unused_indexes":      dc.unusedIndexes,
+		"accounts":            dc.accountActivity,
+		"hosts":               dc.hostActivity,
+		"global_status":       dc.globalStatus,
+		"transactions":        dc.transactions,
+		"statement_histogram": dc.statementHistogram,
+		"stage_progress":      dc.stageProgress,
+		"file_io_category":    dc.fileIoCategory,
+		"file_io_event":       dc.fileIoEvent,
+		"socket_io_type":      dc.socketIoType,
+		"socket_io_address":   dc.socketIoAddress,
+		"memory_by_owner":     dc.memoryByOwner,
+	}
 }
 
 // CurrentTabler returns the currently selected tabler (for display).
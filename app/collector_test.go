@@ -31,19 +31,37 @@ func (m *mockTabler) TotalRowContent() string     { return m.total }
 func (m *mockTabler) EmptyRowContent() string     { return m.empty }
 func (m *mockTabler) WantRelativeStats() bool     { return m.wantRel }
 
-// TestDBCollector_NewDBCollector verifies that NewDBCollector creates all 8 tablers.
+// TestDBCollector_NewDBCollector verifies that NewDBCollector creates all 26 tablers.
 // Since NewDBCollector actually calls pstable.NewTabler which needs a real DB,
 // this test constructs a DBCollector manually with mock tablers to verify structure.
 func TestDBCollector_Structure(t *testing.T) {
 	dc := &DBCollector{
-		fileInfoLatency:  &mockTabler{name: "fileInfoLatency"},
-		tableIoLatency:   &mockTabler{name: "tableIoLatency"},
-		tableIoOps:       &mockTabler{name: "tableIoOps"},
-		tableLockLatency: &mockTabler{name: "tableLockLatency"},
-		mutexLatency:     &mockTabler{name: "mutexLatency"},
-		stagesLatency:    &mockTabler{name: "stagesLatency"},
-		memoryUsage:      &mockTabler{name: "memoryUsage"},
-		userLatency:      &mockTabler{name: "userLatency"},
+		fileInfoLatency:    &mockTabler{name: "fileInfoLatency"},
+		tableIoLatency:     &mockTabler{name: "tableIoLatency"},
+		tableIoOps:         &mockTabler{name: "tableIoOps"},
+		tableLockLatency:   &mockTabler{name: "tableLockLatency"},
+		mutexLatency:       &mockTabler{name: "mutexLatency"},
+		stagesLatency:      &mockTabler{name: "stagesLatency"},
+		memoryUsage:        &mockTabler{name: "memoryUsage"},
+		userLatency:        &mockTabler{name: "userLatency"},
+		statementDigest:    &mockTabler{name: "statementDigest"},
+		runningStatements:  &mockTabler{name: "runningStatements"},
+		lockWaits:          &mockTabler{name: "lockWaits"},
+		metadataLocks:      &mockTabler{name: "metadataLocks"},
+		replication:        &mockTabler{name: "replication"},
+		indexUsage:         &mockTabler{name: "indexUsage"},
+		unusedIndexes:      &mockTabler{name: "unusedIndexes"},
+		accountActivity:    &mockTabler{name: "accountActivity"},
+		hostActivity:       &mockTabler{name: "hostActivity"},
+		globalStatus:       &mockTabler{name: "globalStatus"},
+		transactions:       &mockTabler{name: "transactions"},
+		statementHistogram: &mockTabler{name: "statementHistogram"},
+		stageProgress:      &mockTabler{name: "stageProgress"},
+		fileIoCategory:     &mockTabler{name: "fileIoCategory"},
+		fileIoEvent:        &mockTabler{name: "fileIoEvent"},
+		socketIoType:       &mockTabler{name: "socketIoType"},
+		socketIoAddress:    &mockTabler{name: "socketIoAddress"},
+		memoryByOwner:      &mockTabler{name: "memoryByOwner"},
 	}
 	if dc.fileInfoLatency == nil {
 		t.Error("fileInfoLatency is nil")
@@ -69,6 +87,60 @@ func TestDBCollector_Structure(t *testing.T) {
 	if dc.userLatency == nil {
 		t.Error("userLatency is nil")
 	}
+	if dc.statementDigest == nil {
+		t.Error("statementDigest is nil")
+	}
+	if dc.runningStatements == nil {
+		t.Error("runningStatements is nil")
+	}
+	if dc.lockWaits == nil {
+		t.Error("lockWaits is nil")
+	}
+	if dc.metadataLocks == nil {
+		t.Error("metadataLocks is nil")
+	}
+	if dc.replication == nil {
+		t.Error("replication is nil")
+	}
+	if dc.indexUsage == nil {
+		t.Error("indexUsage is nil")
+	}
+	if dc.unusedIndexes == nil {
+		t.Error("unusedIndexes is nil")
+	}
+	if dc.accountActivity == nil {
+		t.Error("accountActivity is nil")
+	}
+	if dc.hostActivity == nil {
+		t.Error("hostActivity is nil")
+	}
+	if dc.globalStatus == nil {
+		t.Error("globalStatus is nil")
+	}
+	if dc.transactions == nil {
+		t.Error("transactions is nil")
+	}
+	if dc.statementHistogram == nil {
+		t.Error("statementHistogram is nil")
+	}
+	if dc.stageProgress == nil {
+		t.Error("stageProgress is nil")
+	}
+	if dc.fileIoCategory == nil {
+		t.Error("fileIoCategory is nil")
+	}
+	if dc.fileIoEvent == nil {
+		t.Error("fileIoEvent is nil")
+	}
+	if dc.socketIoType == nil {
+		t.Error("socketIoType is nil")
+	}
+	if dc.socketIoAddress == nil {
+		t.Error("socketIoAddress is nil")
+	}
+	if dc.memoryByOwner == nil {
+		t.Error("memoryByOwner is nil")
+	}
 }
 
 // TestDBCollector_Collect tests that Collect delegates to currentTabler.
@@ -83,7 +155,7 @@ func TestDBCollector_Collect(t *testing.T) {
 	}
 }
 
-// TestDBCollector_CollectAll tests that CollectAll calls Collect on all 8 tablers.
+// TestDBCollector_CollectAll tests that CollectAll calls Collect on all 25 collected tablers.
 func TestDBCollector_CollectAll(t *testing.T) {
 	mocks := []*mockTabler{
 		{name: "fileInfoLatency"},
@@ -93,15 +165,51 @@ func TestDBCollector_CollectAll(t *testing.T) {
 		{name: "stagesLatency"},
 		{name: "mutexLatency"},
 		{name: "memoryUsage"},
+		{name: "statementDigest"},
+		{name: "runningStatements"},
+		{name: "lockWaits"},
+		{name: "metadataLocks"},
+		{name: "replication"},
+		{name: "indexUsage"},
+		{name: "unusedIndexes"},
+		{name: "accountActivity"},
+		{name: "hostActivity"},
+		{name: "globalStatus"},
+		{name: "transactions"},
+		{name: "statementHistogram"},
+		{name: "stageProgress"},
+		{name: "fileIoCategory"},
+		{name: "fileIoEvent"},
+		{name: "socketIoType"},
+		{name: "socketIoAddress"},
+		{name: "memoryByOwner"},
 	}
 	dc := &DBCollector{
-		fileInfoLatency:  mocks[0],
-		tableLockLatency: mocks[1],
-		tableIoLatency:   mocks[2],
-		userLatency:      mocks[3],
-		stagesLatency:    mocks[4],
-		mutexLatency:     mocks[5],
-		memoryUsage:      mocks[6],
+		fileInfoLatency:    mocks[0],
+		tableLockLatency:   mocks[1],
+		tableIoLatency:     mocks[2],
+		userLatency:        mocks[3],
+		stagesLatency:      mocks[4],
+		mutexLatency:       mocks[5],
+		memoryUsage:        mocks[6],
+		statementDigest:    mocks[7],
+		runningStatements:  mocks[8],
+		lockWaits:          mocks[9],
+		metadataLocks:      mocks[10],
+		replication:        mocks[11],
+		indexUsage:         mocks[12],
+		unusedIndexes:      mocks[13],
+		accountActivity:    mocks[14],
+		hostActivity:       mocks[15],
+		globalStatus:       mocks[16],
+		transactions:       mocks[17],
+		statementHistogram: mocks[18],
+		stageProgress:      mocks[19],
+		fileIoCategory:     mocks[20],
+		fileIoEvent:        mocks[21],
+		socketIoType:       mocks[22],
+		socketIoAddress:    mocks[23],
+		memoryByOwner:      mocks[24],
 	}
 	dc.CollectAll()
 	for i, m := range mocks {
@@ -111,7 +219,7 @@ func TestDBCollector_CollectAll(t *testing.T) {
 	}
 }
 
-// TestDBCollector_ResetAll tests that ResetAll calls ResetStatistics on all 8 tablers.
+// TestDBCollector_ResetAll tests that ResetAll calls ResetStatistics on all 25 collected tablers.
 func TestDBCollector_ResetAll(t *testing.T) {
 	mocks := []*mockTabler{
 		{name: "fileInfoLatency"},
@@ -121,15 +229,51 @@ func TestDBCollector_ResetAll(t *testing.T) {
 		{name: "stagesLatency"},
 		{name: "mutexLatency"},
 		{name: "memoryUsage"},
+		{name: "statementDigest"},
+		{name: "runningStatements"},
+		{name: "lockWaits"},
+		{name: "metadataLocks"},
+		{name: "replication"},
+		{name: "indexUsage"},
+		{name: "unusedIndexes"},
+		{name: "accountActivity"},
+		{name: "hostActivity"},
+		{name: "globalStatus"},
+		{name: "transactions"},
+		{name: "statementHistogram"},
+		{name: "stageProgress"},
+		{name: "fileIoCategory"},
+		{name: "fileIoEvent"},
+		{name: "socketIoType"},
+		{name: "socketIoAddress"},
+		{name: "memoryByOwner"},
 	}
 	dc := &DBCollector{
-		fileInfoLatency:  mocks[0],
-		tableLockLatency: mocks[1],
-		tableIoLatency:   mocks[2],
-		userLatency:      mocks[3],
-		stagesLatency:    mocks[4],
-		mutexLatency:     mocks[5],
-		memoryUsage:      mocks[6],
+		fileInfoLatency:    mocks[0],
+		tableLockLatency:   mocks[1],
+		tableIoLatency:     mocks[2],
+		userLatency:        mocks[3],
+		stagesLatency:      mocks[4],
+		mutexLatency:       mocks[5],
+		memoryUsage:        mocks[6],
+		statementDigest:    mocks[7],
+		runningStatements:  mocks[8],
+		lockWaits:          mocks[9],
+		metadataLocks:      mocks[10],
+		replication:        mocks[11],
+		indexUsage:         mocks[12],
+		unusedIndexes:      mocks[13],
+		accountActivity:    mocks[14],
+		hostActivity:       mocks[15],
+		globalStatus:       mocks[16],
+		transactions:       mocks[17],
+		statementHistogram: mocks[18],
+		stageProgress:      mocks[19],
+		fileIoCategory:     mocks[20],
+		fileIoEvent:        mocks[21],
+		socketIoType:       mocks[22],
+		socketIoAddress:    mocks[23],
+		memoryByOwner:      mocks[24],
 	}
 	dc.ResetAll()
 	for i, m := range mocks {
@@ -156,3 +300,29 @@ func TestDBCollector_Accessors(t *testing.T) {
 		t.Error("SetCurrentTabler did not update currentTabler")
 	}
 }
+
+// TestDBCollector_MetricsTablers tests the tablers served as metrics.
+func TestDBCollector_MetricsTablers(t *testing.T) {
+	dc := &DBCollector{
+		fileInfoLatency:  &mockTabler{name: "fileInfoLatency"},
+		tableIoLatency:   &mockTabler{name: "tableIoLatency"},
+		tableIoOps:       &mockTabler{name: "tableIoOps"},
+		tableLockLatency: &mockTabler{name: "tableLockLatency"},
+		mutexLatency:     &mockTabler{name: "mutexLatency"},
+		stagesLatency:    &mockTabler{name: "stagesLatency"},
+		memoryUsage:      &mockTabler{name: "memoryUsage"},
+		userLatency:      &mockTabler{name: "userLatency"},
+	}
+	got := map[string]bool{}
+	for _, tabler := range dc.MetricsTablers() {
+		got[tabler.(*mockTabler).name] = true
+	}
+	for _, name := range []string{"tableIoLatency", "fileInfoLatency", "mutexLatency", "stagesLatency", "memoryUsage", "userLatency"} {
+		if !got[name] {
+			t.Errorf("MetricsTablers() is missing %s", name)
+		}
+	}
+	if got["tableIoOps"] {
+		t.Error("MetricsTablers() should not include tableIoOps which shares the tableIoLatency model")
+	}
+}
@@ -0,0 +1,217 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sjmudd/ps-top/config"
+	"github.com/sjmudd/ps-top/connector"
+	"github.com/sjmudd/ps-top/dashboard"
+	"github.com/sjmudd/ps-top/global"
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/pstable"
+	"github.com/sjmudd/ps-top/setupinstruments"
+)
+
+var errNoServers = errors.New("failed to connect to any of the servers")
+
+// server is one of the servers shown in the dashboard. Each server has
+// its own connection and collector.
+type server struct {
+	name             string
+	err              error // why the server can not be used, nil if it can
+	db               *sql.DB
+	status           *global.Status
+	config           *config.Config
+	collector        *DBCollector
+	setupInstruments *setupinstruments.SetupInstruments
+}
+
+// connectServers connects to the servers shown in the dashboard and returns
+// the first server which can be used. Servers which can not be used are kept
+// so that their errors can be shown.
+func (app *App) connectServers(connectorFlags connector.Config, settings Settings) (*server, error) {
+	connectors, errs := connector.NewConnectors(connectorFlags, settings.Servers)
+
+	var first *server
+	for i, conn := range connectors {
+		s := &server{name: conn.Name(), err: errs[i], db: conn.DB}
+		if s.err == nil {
+			s.setup(settings)
+		}
+		if s.err == nil && first == nil {
+			first = s
+		}
+		app.servers = append(app.servers, s)
+	}
+	if first == nil {
+		for _, s := range app.servers {
+			log.Printf("app.connectServers: %s: %v", s.name, s.err)
+		}
+		return nil, errNoServers
+	}
+
+	servers := make([]dashboard.Server, 0, len(app.servers))
+	for _, s := range app.servers {
+		servers = append(servers, dashboard.Server{Name: s.name, Err: s.err})
+	}
+	app.dashboard = dashboard.NewDashboard(servers, settings.Layout)
+
+	return first, nil
+}
+
+// setup prepares a connected server for collecting data, closing the
+// connection and recording the error if it can not be used.
+func (s *server) setup(settings Settings) {
+	s.status = global.NewStatus(s.db)
+	variables := global.NewVariables(s.db)
+	if err := performanceSchemaEnabled(variables); err != nil {
+		s.err = err
+		_ = s.db.Close()
+		s.db = nil
+		return
+	}
+
+	s.config = config.NewConfig(s.status, variables, settings.Filter, true)
+	s.setupInstruments = setupinstruments.NewSetupInstruments(s.db)
+	s.setupInstruments.EnableMonitoring()
+	s.collector = NewDBCollector(s.config, s.db)
+}
+
+// newDashboardCollector returns a DBCollector whose tablers show the same
+// view of every server. Collecting a view collects it from each server
+// concurrently.
+func (app *App) newDashboardCollector() *DBCollector {
+	tabler := func(get func(*DBCollector) pstable.Tabler) pstable.Tabler {
+		tablers := make([]pstable.Tabler, len(app.servers))
+		for i, s := range app.servers {
+			if s.collector != nil {
+				tablers[i] = get(s.collector)
+			}
+		}
+		return app.dashboard.NewTabler(tablers)
+	}
+
+	return &DBCollector{
+		config:             app.config,
+		fileInfoLatency:    tabler(func(dc *DBCollector) pstable.Tabler { return dc.fileInfoLatency }),
+		tableIoLatency:     tabler(func(dc *DBCollector) pstable.Tabler { return dc.tableIoLatency }),
+		tableIoOps:         tabler(func(dc *DBCollector) pstable.Tabler { return dc.tableIoOps }),
+		tableLockLatency:   tabler(func(dc *DBCollector) pstable.Tabler { return dc.tableLockLatency }),
+		mutexLatency:       tabler(func(dc *DBCollector) pstable.Tabler { return dc.mutexLatency }),
+		stagesLatency:      tabler(func(dc *DBCollector) pstable.Tabler { return dc.stagesLatency }),
+		memoryUsage:        tabler(func(dc *DBCollector) pstable.Tabler { return dc.memoryUsage }),
+		userLatency:        tabler(func(dc *DBCollector) pstable.Tabler { return dc.userLatency }),
+		statementDigest:    tabler(func(dc *DBCollector) pstable.Tabler { return dc.statementDigest }),
+		runningStatements:  tabler(func(dc *DBCollector) pstable.Tabler { return dc.runningStatements }),
+		lockWaits:          tabler(func(dc *DBCollector) pstable.Tabler { return dc.lockWaits }),
+		metadataLocks:      tabler(func(dc *DBCollector) pstable.Tabler { return dc.metadataLocks }),
+		replication:        tabler(func(dc *DBCollector) pstable.Tabler { return dc.replication }),
+		indexUsage:         tabler(func(dc *DBCollector) pstable.Tabler { return dc.indexUsage }),
+		unusedIndexes:      tabler(func(dc *DBCollector) pstable.Tabler { return dc.unusedIndexes }),
+		accountActivity:    tabler(func(dc *DBCollector) pstable.Tabler { return dc.accountActivity }),
+		hostActivity:       tabler(func(dc *DBCollector) pstable.Tabler { return dc.hostActivity }),
+		globalStatus:       tabler(func(dc *DBCollector) pstable.Tabler { return dc.globalStatus }),
+		transactions:       tabler(func(dc *DBCollector) pstable.Tabler { return dc.transactions }),
+		statementHistogram: tabler(func(dc *DBCollector) pstable.Tabler { return dc.statementHistogram }),
+		stageProgress:      tabler(func(dc *DBCollector) pstable.Tabler { return dc.stageProgress }),
+		fileIoCategory:     tabler(func(dc *DBCollector) pstable.Tabler { return dc.fileIoCategory }),
+		fileIoEvent:        tabler(func(dc *DBCollector) pstable.Tabler { return dc.fileIoEvent }),
+		socketIoType:       tabler(func(dc *DBCollector) pstable.Tabler { return dc.socketIoType }),
+		socketIoAddress:    tabler(func(dc *DBCollector) pstable.Tabler { return dc.socketIoAddress }),
+		memoryByOwner:      tabler(func(dc *DBCollector) pstable.Tabler { return dc.memoryByOwner }),
+	}
+}
+
+// setWantRelativeStats changes whether relative statistics are wanted for
+// all the servers being collected from
+func (app *App) setWantRelativeStats(want bool) {
+	app.config.SetWantRelativeStats(want)
+	for _, s := range app.servers {
+		if s.config != nil {
+			s.config.SetWantRelativeStats(want)
+		}
+	}
+}
+
+// failed returns the error of the server at index i, including any error
+// found while collecting from it after it was set up
+func (app *App) failed(i int) error {
+	if err := app.dashboard.Servers()[i].Err; err != nil {
+		return err
+	}
+	return app.servers[i].err
+}
+
+// closeServers restores the configuration of the dashboard's servers and
+// closes their connections. The configuration of a server which failed is
+// not restored as it is likely to fail again.
+func (app *App) closeServers() {
+	for i, s := range app.servers {
+		if s.db == nil {
+			continue
+		}
+		if app.failed(i) == nil {
+			if err := log.Recover(s.setupInstruments.RestoreConfiguration); err != nil {
+				log.Printf("app.closeServers: %s: %v", s.name, err)
+			}
+		}
+		_ = s.db.Close()
+	}
+}
+
+// dashboardConfig describes all the dashboard's servers on the top line
+type dashboardConfig struct {
+	*config.Config
+	app *App
+}
+
+// Hostname returns the number of servers shown
+func (c dashboardConfig) Hostname() string {
+	return fmt.Sprintf("%d servers", len(c.app.servers))
+}
+
+// Port returns an empty port as there are several servers
+func (c dashboardConfig) Port() string {
+	return ""
+}
+
+// BindAddress returns "*" so that the hostname is shown
+func (c dashboardConfig) BindAddress() string {
+	return "*"
+}
+
+// MySQLVersion returns the different versions of the servers
+func (c dashboardConfig) MySQLVersion() string {
+	var versions []string
+	for _, s := range c.app.servers {
+		if s.config != nil && !slices.Contains(versions, s.config.MySQLVersion()) {
+			versions = append(versions, s.config.MySQLVersion())
+		}
+	}
+	return strings.Join(versions, ",")
+}
+
+// Uptime returns the shortest uptime of the servers which have not failed.
+// A server which fails while its uptime is read is marked as failed.
+func (c dashboardConfig) Uptime() int {
+	uptime := 0
+	for i, s := range c.app.servers {
+		if c.app.failed(i) != nil {
+			continue
+		}
+		var up int
+		if err := log.Recover(func() { up = s.config.Uptime() }); err != nil {
+			log.Printf("dashboardConfig.Uptime: %s: %v", s.name, err)
+			c.app.dashboard.SetError(i, err)
+			continue
+		}
+		if uptime == 0 || up < uptime {
+			uptime = up
+		}
+	}
+	return uptime
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sjmudd/ps-top/export"
+	"github.com/sjmudd/ps-top/log"
+)
+
+const (
+	metricsPath         = "/metrics"
+	readHeaderTimeout   = 5 * time.Second
+	shutdownGracePeriod = 5 * time.Second
+)
+
+// runExporter collects data for all tablers every interval and serves
+// their absolute values as Prometheus metrics until we are asked to stop.
+func (app *App) runExporter() {
+	defer app.Cleanup()
+
+	log.Printf("app.runExporter(): serving metrics on %s%s", app.listener.Addr(), metricsPath)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(metricsPath, app.serveMetrics)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.Serve(app.listener)
+	}()
+
+	for !app.finished {
+		select {
+		case sig := <-app.signalHandler.Channel():
+			log.Println("Caught signal: ", sig)
+			app.finished = true
+		case err := <-serverErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("app.runExporter: server failed: %v", err)
+			}
+			app.finished = true
+		case <-app.waiter.WaitUntilNextPeriod():
+			app.collectMetrics()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("app.runExporter: shutdown failed: %v", err)
+	}
+}
+
+// collectMetrics collects data for all the tablers served as metrics.
+func (app *App) collectMetrics() {
+	start := time.Now()
+
+	app.mu.Lock()
+	for _, t := range app.collector.MetricsTablers() {
+		t.Collect()
+	}
+	app.mu.Unlock()
+
+	app.waiter.CollectedNow()
+	log.Println("app.collectMetrics() took", time.Since(start))
+}
+
+// serveMetrics writes the most recently collected data as Prometheus metrics.
+func (app *App) serveMetrics(w http.ResponseWriter, _ *http.Request) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	var data []export.Data
+	for _, t := range app.collector.MetricsTablers() {
+		if d, ok := t.(export.Data); ok {
+			data = append(data, d)
+		}
+	}
+
+	w.Header().Set("Content-Type", export.PrometheusContentType)
+	if err := export.WritePrometheus(w, data...); err != nil {
+		log.Printf("app.serveMetrics: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/record"
+)
+
+// recordedModels returns the names of the models which are recorded
+func recordedModels() []string {
+	return slices.Sorted(maps.Keys((&DBCollector{}).RecordedTablers()))
+}
+
+// recordFrame writes the raw data last collected by each model to the
+// recording. If it can not be written recording stops but ps-top continues.
+func (app *App) recordFrame() {
+	frame := record.Frame{
+		Uptime:    app.config.Uptime(),
+		Snapshots: make(map[string]record.Snapshot),
+	}
+	for name, tabler := range app.collector.RecordedTablers() {
+		r, ok := tabler.(record.Recordable)
+		if !ok {
+			continue
+		}
+		rows, collected := r.Snapshot()
+		frame.Snapshots[name] = record.Snapshot{Collected: collected, Rows: rows}
+	}
+
+	if err := app.recorder.Write(frame); err != nil {
+		log.Printf("app.recordFrame: stopping recording: %v", err)
+		if err := app.recorder.Close(); err != nil {
+			log.Printf("app.recordFrame: failed to close recording: %v", err)
+		}
+		app.recorder = nil
+	}
+}
+
+// replayFrame provides the current recorded frame to each model and
+// processes it as if it had just been collected from the database.
+// Nothing is collected if the frame can not be replayed as there is no
+// database to collect from instead.
+func (app *App) replayFrame() error {
+	frame := app.replayer.Frame()
+
+	app.status.Set("Uptime", frame.Uptime)
+	for name, tabler := range app.collector.RecordedTablers() {
+		r, ok := tabler.(record.Recordable)
+		if !ok {
+			continue
+		}
+		snapshot, found := frame.Snapshots[name]
+		if !found {
+			return fmt.Errorf("app.replayFrame: no data recorded for %s", name)
+		}
+		if err := r.Replay(snapshot.Rows, snapshot.Collected); err != nil {
+			return fmt.Errorf("app.replayFrame: %s: %w", name, err)
+		}
+	}
+	app.collector.CollectAll()
+	return nil
+}
+
+// startReplay uses the first recorded frame as the baseline and then
+// moves to the second frame (if there is one) so there is something to see.
+func (app *App) startReplay() error {
+	log.Println("app.startReplay: replaying first frame as baseline")
+	if err := app.replayFrame(); err != nil {
+		return err
+	}
+	app.collector.ResetAll()
+	if app.replayer.Next() {
+		return app.replayFrame()
+	}
+	return nil
+}
+
+// showReplayFrame replays and displays the current frame, returning true
+// if the frame can not be replayed and ps-top should finish.
+func (app *App) showReplayFrame() bool {
+	if err := app.replayFrame(); err != nil {
+		// as for EventError finish so that the deferred Cleanup() runs
+		log.Printf("Quitting because the recording can not be replayed: %v", err)
+		app.finished = true
+		return true
+	}
+	app.Display()
+	return false
+}
+
+// runBatchReplay writes the current view for each recorded frame without
+// waiting between them until the requested number have been shown.
+func (app *App) runBatchReplay() {
+	for shown := 0; app.count == 0 || shown < app.count; shown++ {
+		app.output()
+		if !app.replayer.Next() {
+			break
+		}
+		if err := app.replayFrame(); err != nil {
+			log.Printf("app.runBatchReplay: stopping: %v", err)
+			break
+		}
+	}
+}
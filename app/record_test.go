@@ -0,0 +1,103 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sjmudd/ps-top/config"
+	"github.com/sjmudd/ps-top/export"
+	"github.com/sjmudd/ps-top/global"
+	"github.com/sjmudd/ps-top/model/tableio"
+	"github.com/sjmudd/ps-top/record"
+)
+
+// newRecordTestApp returns an App without a database whose models are
+// fed with empty data so that they can be collected.
+func newRecordTestApp() *App {
+	status := global.NewFixedStatus()
+	variables := global.NewFixedVariables(map[string]string{"hostname": "db1"})
+	a := &App{
+		config: config.NewConfig(status, variables, nil, true),
+		status: status,
+	}
+	a.collector = NewDBCollector(a.config, nil)
+	for _, tabler := range a.collector.RecordedTablers() {
+		r := tabler.(record.Recordable)
+		rows, collected := r.Snapshot()
+		_ = r.Replay(rows, collected)
+	}
+	return a
+}
+
+// tableIoWait returns the SumTimerWait of the single table I/O row
+func tableIoWait(t *testing.T, a *App) uint64 {
+	t.Helper()
+	rows := a.collector.tableIoLatency.(export.Data).RawResults().([]tableio.Row)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 table I/O row, got %d", len(rows))
+	}
+	return rows[0].SumTimerWait
+}
+
+// TestRecordReplay records a few collections and checks that replaying
+// them produces the same relative values with no database.
+func TestRecordReplay(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "test.pstop")
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	a := newRecordTestApp()
+	recorder, err := record.NewRecorder(filename, record.Header{Views: []string{"table_io_latency"}})
+	if err != nil {
+		t.Fatalf("NewRecorder() failed: %v", err)
+	}
+	a.recorder = recorder
+
+	// stand in for the database by replaying increasing values
+	source := a.collector.tableIoLatency.(record.Recordable)
+	for i, wait := range []uint64{100, 250, 400} {
+		a.status.Set("Uptime", 1000+i)
+		_ = source.Replay([]tableio.Row{{Name: "db.t", SumTimerWait: wait}}, start.Add(time.Duration(i)*time.Second))
+		a.collector.CollectAll()
+		a.recordFrame()
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	replayer, err := record.NewReplayer(filename, recordedModels())
+	if err != nil {
+		t.Fatalf("NewReplayer() failed: %v", err)
+	}
+	b := newRecordTestApp()
+	b.replayer = replayer
+	if err := b.startReplay(); err != nil {
+		t.Fatalf("startReplay() failed: %v", err)
+	}
+
+	if got := tableIoWait(t, b); got != 150 {
+		t.Errorf("after start: expected relative wait 150, got %d", got)
+	}
+	if got := b.config.Uptime(); got != 1001 {
+		t.Errorf("after start: expected uptime 1001, got %d", got)
+	}
+	if !b.collector.tableIoLatency.LastCollectTime().Equal(start.Add(time.Second)) {
+		t.Errorf("after start: unexpected collection time %v", b.collector.tableIoLatency.LastCollectTime())
+	}
+
+	b.replayer.Next()
+	if err := b.replayFrame(); err != nil {
+		t.Fatalf("replayFrame() failed: %v", err)
+	}
+	if got := tableIoWait(t, b); got != 300 {
+		t.Errorf("after next: expected relative wait 300, got %d", got)
+	}
+
+	b.replayer.Prev()
+	if err := b.replayFrame(); err != nil {
+		t.Fatalf("replayFrame() failed: %v", err)
+	}
+	if got := tableIoWait(t, b); got != 150 {
+		t.Errorf("after prev: expected relative wait 150, got %d", got)
+	}
+}
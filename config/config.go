@@ -6,9 +6,9 @@ package config
 import (
 	"strings"
 
-	"github.com/sjmudd/anonymiser"
 	"github.com/sjmudd/ps-top/global"
 	"github.com/sjmudd/ps-top/model/filter"
+	"github.com/sjmudd/ps-top/utils"
 )
 
 // Config holds the common information
@@ -36,7 +36,7 @@ func (c Config) DatabaseFilter() *filter.DatabaseFilter {
 
 // Hostname returns the current short hostname
 func (c Config) Hostname() string {
-	hostname := anonymiser.Anonymise("hostname", c.variables.Get("hostname"))
+	hostname := utils.Anonymise("hostname", c.variables.Get("hostname"))
 	if index := strings.Index(hostname, "."); index >= 0 {
 		hostname = hostname[0:index]
 	}
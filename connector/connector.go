@@ -39,6 +39,7 @@ type Config struct {
 
 // Connector contains information on how to connect to MySQL
 type Connector struct {
+	name   string // name of the target connected to, if connecting to several servers
 	method Method
 	config mysql_defaults_file.Config
 	DB     *sql.DB
@@ -49,6 +50,12 @@ func (c Connector) DefaultsFile() string {
 	return c.config.Filename
 }
 
+// Name returns the name of the target connected to, empty unless the
+// Connector was created by NewConnectors
+func (c Connector) Name() string {
+	return c.name
+}
+
 // SetMethod records the method used to connect to the database
 func (c *Connector) SetMethod(method Method) {
 	c.method = method
@@ -0,0 +1,189 @@
+package connector
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sjmudd/mysql_defaults_file"
+	go_ini "github.com/vaughan0/go-ini"
+
+	"github.com/sjmudd/ps-top/log"
+)
+
+const (
+	defaultsFile = "~/.my.cnf" // defaults file used if none is given
+	groupPrefix  = "@"         // prefix of a target naming a group of the defaults file
+	clientGroup  = "client"    // defaults file group read by all MySQL clients
+)
+
+// ParseTargets splits a comma separated list of targets, ignoring empty entries.
+// A target is one of:
+//   - host[:port] to connect to over TCP.
+//   - /path/to/mysql.sock to connect using a unix socket.
+//   - @group to connect using the settings of [group] in the defaults file,
+//     which override those of the [client] group.
+func ParseTargets(list string) []string {
+	var targets []string
+	for _, target := range strings.Split(list, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// NewConnectors returns a Connector for each of the given targets, connecting
+// to them concurrently. Connection failures do not stop the other targets
+// being connected to: errs[i] holds the reason targets[i] could not be used
+// and connectors[i] is still provided so that it can be named.
+// Settings in cfg such as --user and --password are used for host and socket
+// targets, otherwise the values in the [client] group of the defaults file
+// are used if it exists.
+func NewConnectors(cfg Config, targets []string) (connectors []*Connector, errs []error) {
+	connectors = make([]*Connector, len(targets))
+	errs = make([]error, len(targets))
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		connectors[i] = &Connector{name: target}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = connectors[i].connectTarget(cfg, target)
+			if errs[i] != nil {
+				log.Printf("NewConnectors: %s: %v", target, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return connectors, errs
+}
+
+// connectTarget connects to the given target
+func (c *Connector) connectTarget(cfg Config, target string) error {
+	filename := defaultsFile
+	if cfg.DefaultsFile != nil && *cfg.DefaultsFile != "" {
+		filename = *cfg.DefaultsFile
+	}
+
+	var err error
+	if group, ok := strings.CutPrefix(target, groupPrefix); ok {
+		c.config, err = groupConfig(filename, group)
+	} else {
+		c.config, err = targetConfig(cfg, filename, target)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.method = ConnectByConfig
+	if err := c.Connect(); err != nil {
+		if c.DB != nil {
+			_ = c.DB.Close()
+			c.DB = nil
+		}
+		return err
+	}
+	return nil
+}
+
+// targetConfig returns the configuration to connect to a host or socket target
+func targetConfig(cfg Config, filename, target string) (mysql_defaults_file.Config, error) {
+	// credentials come from the command line or the [client] group if available
+	var config mysql_defaults_file.Config
+	if file, err := loadDefaultsFile(filename); err == nil {
+		applyGroup(&config, file.Section(clientGroup))
+		config.Host, config.Socket, config.Port, config.Database = "", "", 0, ""
+	}
+	if cfg.User != nil && *cfg.User != "" {
+		config.User = *cfg.User
+	}
+	if cfg.Password != nil && *cfg.Password != "" {
+		config.Password = *cfg.Password
+	}
+
+	if strings.HasPrefix(target, "/") {
+		config.Socket = target
+		return config, nil
+	}
+
+	host, port, found := strings.Cut(target, ":")
+	config.Host = host
+	if found {
+		p, err := strconv.Atoi(port)
+		if err != nil || p <= 0 || p > math.MaxUint16 {
+			return config, fmt.Errorf("invalid port in %q", target)
+		}
+		config.Port = uint16(p) // nolint:gosec
+	} else if cfg.Port != nil && *cfg.Port > 0 && *cfg.Port <= math.MaxUint16 {
+		config.Port = uint16(*cfg.Port) // nolint:gosec
+	}
+
+	return config, nil
+}
+
+// groupConfig returns the configuration held in the [client] group of the
+// defaults file overridden by the settings of the named group
+func groupConfig(filename, group string) (mysql_defaults_file.Config, error) {
+	var config mysql_defaults_file.Config
+
+	file, err := loadDefaultsFile(filename)
+	if err != nil {
+		return config, err
+	}
+	section := file.Section(group)
+	if len(section) == 0 {
+		return config, fmt.Errorf("no group [%s] in defaults-file %q", group, filename)
+	}
+	applyGroup(&config, file.Section(clientGroup))
+	applyGroup(&config, section)
+
+	return config, nil
+}
+
+// loadDefaultsFile reads the given defaults file, expanding a leading ~ to $HOME
+func loadDefaultsFile(filename string) (go_ini.File, error) {
+	if rest, ok := strings.CutPrefix(filename, "~"); ok {
+		filename = os.Getenv("HOME") + rest
+	}
+	file, err := go_ini.LoadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not load defaults-file %q: %w", filename, err)
+	}
+	return file, nil
+}
+
+// applyGroup sets the connection settings found in the given defaults file group
+func applyGroup(config *mysql_defaults_file.Config, section go_ini.Section) {
+	if user, ok := section["user"]; ok {
+		config.User = unquote(user)
+	}
+	if password, ok := section["password"]; ok {
+		config.Password = unquote(password)
+	}
+	if host, ok := section["host"]; ok {
+		config.Host = host
+	}
+	if socket, ok := section["socket"]; ok {
+		config.Socket = socket
+	}
+	if port, ok := section["port"]; ok {
+		if p, err := strconv.Atoi(port); err == nil && p > 0 && p <= math.MaxUint16 {
+			config.Port = uint16(p) // nolint:gosec
+		}
+	}
+}
+
+// unquote removes surrounding whitespace and matching quotes from a defaults file value
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
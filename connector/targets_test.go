@@ -0,0 +1,47 @@
+package connector
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseTargets(t *testing.T) {
+	got := ParseTargets(" primary:3307, ,@replica,/tmp/mysql.sock,")
+	want := []string{"primary:3307", "@replica", "/tmp/mysql.sock"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseTargets() = %q, want %q", got, want)
+	}
+}
+
+func TestTargetConfigs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "my.cnf")
+	content := "[client]\nuser = monitor\npassword = 'secret'\nhost = localhost\n\n[replica]\nhost = replica1\nport = 3307\n"
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := groupConfig(filename, "replica")
+	if err != nil {
+		t.Fatalf("groupConfig() failed: %v", err)
+	}
+	if config.User != "monitor" || config.Password != "secret" || config.Host != "replica1" || config.Port != 3307 {
+		t.Errorf("groupConfig() = %+v, want [client] credentials with the [replica] host and port", config)
+	}
+	if _, err := groupConfig(filename, "missing"); err == nil {
+		t.Errorf("groupConfig() of a missing group did not fail")
+	}
+
+	user, port := "", 0
+	config, err = targetConfig(Config{User: &user, Port: &port}, filename, "primary:3308")
+	if err != nil {
+		t.Fatalf("targetConfig() failed: %v", err)
+	}
+	if config.User != "monitor" || config.Host != "primary" || config.Port != 3308 {
+		t.Errorf("targetConfig() = %+v, want [client] credentials with host primary:3308", config)
+	}
+	if _, err := targetConfig(Config{}, filename, "primary:none"); err == nil {
+		t.Errorf("targetConfig() with an invalid port did not fail")
+	}
+}
@@ -0,0 +1,381 @@
+// Package dashboard combines the data collected from several MySQL servers
+// so that the same view of each of them can be shown together.
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/pstable"
+)
+
+// Layout determines how the rows of the different servers are shown
+type Layout int
+
+const (
+	// Stacked shows the rows of each server one after the other
+	Stacked Layout = iota
+	// Merged shows the rows of all servers sorted together with a server column
+	Merged
+)
+
+const maxServerWidth = 20 // maximum width of the server column
+
+var layoutNames = []string{"stacked", "merged"}
+
+// String returns the name of the layout
+func (l Layout) String() string {
+	if l < 0 || int(l) >= len(layoutNames) {
+		return "unknown"
+	}
+	return layoutNames[l]
+}
+
+// ParseLayout returns the layout with the given name
+func ParseLayout(name string) (Layout, error) {
+	for i, layoutName := range layoutNames {
+		if name == layoutName {
+			return Layout(i), nil
+		}
+	}
+	return Stacked, fmt.Errorf("unknown layout %q, expected one of: %s", name, strings.Join(layoutNames, ", "))
+}
+
+// Server is one of the servers shown in the dashboard
+type Server struct {
+	Name string
+	Err  error // why data can not be collected from the server, nil if it can
+}
+
+// Dashboard holds the settings shared by each view of the dashboard
+type Dashboard struct {
+	servers []Server
+	layout  Layout
+	rows    int // number of rows which can be shown, 0 if not limited
+}
+
+// NewDashboard returns a Dashboard of the given servers
+func NewDashboard(servers []Server, layout Layout) *Dashboard {
+	return &Dashboard{
+		servers: servers,
+		layout:  layout,
+	}
+}
+
+// Servers returns the servers shown in the dashboard
+func (d *Dashboard) Servers() []Server {
+	return d.servers
+}
+
+// SetError marks the server at index i as failed so that its error is
+// shown instead of its rows. Servers may be marked concurrently as long as
+// each is only marked by one goroutine.
+func (d *Dashboard) SetError(i int, err error) {
+	d.servers[i].Err = err
+}
+
+// Layout returns the layout in use
+func (d *Dashboard) Layout() Layout {
+	return d.layout
+}
+
+// ToggleLayout changes between the stacked and merged layouts
+func (d *Dashboard) ToggleLayout() {
+	d.layout = (d.layout + 1) % Layout(len(layoutNames))
+}
+
+// SetRows sets the number of rows which can be shown so that the stacked
+// layout can share them between the servers. 0 shows all the rows.
+func (d *Dashboard) SetRows(rows int) {
+	d.rows = rows
+}
+
+// serverWidth returns the width of the server column
+func (d *Dashboard) serverWidth() int {
+	width := len("Server")
+	for _, server := range d.servers {
+		width = max(width, len(server.Name))
+	}
+	return min(width, maxServerWidth)
+}
+
+// serverColumn returns the server name formatted for the server column
+func (d *Dashboard) serverColumn(name string) string {
+	width := d.serverWidth()
+	if len(name) > width {
+		name = name[:width]
+	}
+	return fmt.Sprintf("%-*s ", width, name)
+}
+
+// Tabler shows the same view of each server in the dashboard
+type Tabler struct {
+	dashboard *Dashboard
+	tablers   []pstable.Tabler // tablers[i] collects from servers[i], nil if the server has an error
+}
+
+// NewTabler returns a Tabler combining the given tablers, one per server in
+// the same order as the servers of the dashboard. The tabler of a server
+// with an error should be nil.
+func (d *Dashboard) NewTabler(tablers []pstable.Tabler) *Tabler {
+	return &Tabler{
+		dashboard: d,
+		tablers:   tablers,
+	}
+}
+
+// tabler returns the tabler of the server at index i, nil if the server has an error
+func (t *Tabler) tabler(i int) pstable.Tabler {
+	if t.dashboard.servers[i].Err != nil {
+		return nil
+	}
+	return t.tablers[i]
+}
+
+// first returns the tabler of the first server without an error, nil if there is none.
+// It provides the settings which are the same for every server.
+func (t *Tabler) first() pstable.Tabler {
+	for i := range t.tablers {
+		if tabler := t.tabler(i); tabler != nil {
+			return tabler
+		}
+	}
+	return nil
+}
+
+// each calls fn for the tabler of every server without an error
+func (t *Tabler) each(fn func(pstable.Tabler)) {
+	for i := range t.tablers {
+		if tabler := t.tabler(i); tabler != nil {
+			fn(tabler)
+		}
+	}
+}
+
+// Collect collects data from all the servers concurrently. A server which
+// fails while collecting, e.g. as it has gone away, is marked with the
+// error and no longer collected from while the other servers continue.
+func (t *Tabler) Collect() {
+	var wg sync.WaitGroup
+	for i := range t.tablers {
+		tabler := t.tabler(i)
+		if tabler == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := log.Recover(tabler.Collect); err != nil {
+				log.Printf("dashboard: collection from %s failed: %v", t.dashboard.servers[i].Name, err)
+				t.dashboard.SetError(i, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// ResetStatistics resets the statistics of all the servers
+func (t *Tabler) ResetStatistics() {
+	t.each(func(tabler pstable.Tabler) { tabler.ResetStatistics() })
+}
+
+// Description describes the view and the layout used
+func (t *Tabler) Description() string {
+	description := fmt.Sprintf("%d servers, %s", len(t.tablers), t.dashboard.layout)
+	if first := t.first(); first != nil {
+		description += ": " + first.Description()
+	}
+	return description
+}
+
+// Headings returns the view's headings, with a server column if merged
+func (t *Tabler) Headings() string {
+	first := t.first()
+	if first == nil {
+		return ""
+	}
+	if t.dashboard.layout == Merged {
+		return t.dashboard.serverColumn("Server") + first.Headings()
+	}
+	return first.Headings()
+}
+
+// FirstCollectTime returns the time of the first server's first collection
+func (t *Tabler) FirstCollectTime() time.Time {
+	if first := t.first(); first != nil {
+		return first.FirstCollectTime()
+	}
+	return time.Time{}
+}
+
+// LastCollectTime returns the time of the first server's last collection
+func (t *Tabler) LastCollectTime() time.Time {
+	if first := t.first(); first != nil {
+		return first.LastCollectTime()
+	}
+	return time.Time{}
+}
+
+// HaveRelativeStats returns true if the view has relative statistics
+func (t *Tabler) HaveRelativeStats() bool {
+	first := t.first()
+	return first != nil && first.HaveRelativeStats()
+}
+
+// WantRelativeStats returns true if relative statistics are wanted
+func (t *Tabler) WantRelativeStats() bool {
+	first := t.first()
+	return first != nil && first.WantRelativeStats()
+}
+
+// RowContent returns the rows of all the servers using the current layout.
+// Servers with an error are shown as a single row with the error.
+func (t *Tabler) RowContent() []string {
+	if t.dashboard.layout == Merged {
+		return t.mergedContent()
+	}
+	return t.stackedContent()
+}
+
+// stackedContent returns a line naming each server followed by its rows.
+// If the number of rows is limited each server shows the same number of rows.
+func (t *Tabler) stackedContent() []string {
+	servers := t.dashboard.servers
+	perServer := 0
+	if t.dashboard.rows > 0 && len(servers) > 0 {
+		perServer = max(t.dashboard.rows/len(servers)-1, 1)
+	}
+
+	var content []string
+	for i, server := range servers {
+		if server.Err != nil {
+			content = append(content, fmt.Sprintf("[%s] error: %v", server.Name, server.Err))
+			continue
+		}
+		tabler := t.tabler(i)
+		content = append(content, fmt.Sprintf("[%s] %s", server.Name, tabler.Description()))
+		rows := tabler.RowContent()
+		if perServer > 0 && len(rows) > perServer {
+			rows = rows[:perServer]
+		}
+		content = append(content, rows...)
+	}
+	return content
+}
+
+// mergedContent returns the rows of all servers sorted together, each
+// prefixed by the server name. Servers with an error are shown first.
+func (t *Tabler) mergedContent() []string {
+	var content []string
+	for _, server := range t.dashboard.servers {
+		if server.Err != nil {
+			content = append(content, t.dashboard.serverColumn(server.Name)+fmt.Sprintf("error: %v", server.Err))
+		}
+	}
+
+	merger, ok := t.first().(pstable.Merger)
+	if !ok {
+		// rows can not be sorted together so show them server by server
+		for i := range t.tablers {
+			tabler := t.tabler(i)
+			if tabler == nil {
+				continue
+			}
+			for _, row := range tabler.RowContent() {
+				content = append(content, t.dashboard.serverColumn(t.dashboard.servers[i].Name)+row)
+			}
+		}
+		return content
+	}
+
+	tablers := make([]any, len(t.tablers))
+	for i := range t.tablers {
+		if tabler := t.tabler(i); tabler != nil {
+			tablers[i] = tabler
+		}
+	}
+	for _, row := range merger.MergeContent(tablers) {
+		content = append(content, t.dashboard.serverColumn(t.dashboard.servers[row.Source].Name)+row.Content)
+	}
+	return content
+}
+
+// TotalRowContent returns an empty row as totals of different servers are not combined
+func (t *Tabler) TotalRowContent() string {
+	return ""
+}
+
+// EmptyRowContent returns an empty row
+func (t *Tabler) EmptyRowContent() string {
+	first := t.first()
+	if first == nil {
+		return ""
+	}
+	if t.dashboard.layout == Merged {
+		return t.dashboard.serverColumn("") + first.EmptyRowContent()
+	}
+	return first.EmptyRowContent()
+}
+
+// NextSortKey sorts the rows of every server on the next column
+func (t *Tabler) NextSortKey() {
+	t.each(func(tabler pstable.Tabler) {
+		if sorter, ok := tabler.(pstable.Sorter); ok {
+			sorter.NextSortKey()
+		}
+	})
+}
+
+// ReverseSort reverses the sort order of every server
+func (t *Tabler) ReverseSort() {
+	t.each(func(tabler pstable.Tabler) {
+		if sorter, ok := tabler.(pstable.Sorter); ok {
+			sorter.ReverseSort()
+		}
+	})
+}
+
+// NextGrouping groups the rows of every server in the next way
+func (t *Tabler) NextGrouping() {
+	t.each(func(tabler pstable.Tabler) {
+		if grouper, ok := tabler.(pstable.Grouper); ok {
+			grouper.NextGrouping()
+		}
+	})
+}
+
+// SortHeading returns the heading of the column sorted on
+func (t *Tabler) SortHeading() string {
+	if sorter, ok := t.first().(pstable.Sorter); ok {
+		return sorter.SortHeading()
+	}
+	return ""
+}
+
+// SortReversed returns true if the sort order is reversed
+func (t *Tabler) SortReversed() bool {
+	if sorter, ok := t.first().(pstable.Sorter); ok {
+		return sorter.SortReversed()
+	}
+	return false
+}
+
+// SetFilter filters the rows of every server
+func (t *Tabler) SetFilter(pattern string) {
+	t.each(func(tabler pstable.Tabler) {
+		if filterer, ok := tabler.(pstable.Filterer); ok {
+			filterer.SetFilter(pattern)
+		}
+	})
+}
+
+// Filter returns the pattern used to filter rows
+func (t *Tabler) Filter() string {
+	if filterer, ok := t.first().(pstable.Filterer); ok {
+		return filterer.Filter()
+	}
+	return ""
+}
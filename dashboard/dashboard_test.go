@@ -0,0 +1,165 @@
+package dashboard
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/pstable"
+)
+
+// mockTabler provides fixed rows for a server
+type mockTabler struct {
+	rows      []string
+	collected int
+	filter    string
+	fatal     string // if set Collect() fails with this message
+}
+
+func (m *mockTabler) Collect() {
+	if m.fatal != "" {
+		log.Fatal(m.fatal)
+	}
+	m.collected++
+}
+
+func (m *mockTabler) Description() string         { return "Mock" }
+func (m *mockTabler) EmptyRowContent() string     { return "" }
+func (m *mockTabler) HaveRelativeStats() bool     { return true }
+func (m *mockTabler) Headings() string            { return "Value|Name" }
+func (m *mockTabler) FirstCollectTime() time.Time { return time.Time{} }
+func (m *mockTabler) LastCollectTime() time.Time  { return time.Time{} }
+func (m *mockTabler) RowContent() []string        { return m.rows }
+func (m *mockTabler) ResetStatistics()            {}
+func (m *mockTabler) TotalRowContent() string     { return "" }
+func (m *mockTabler) WantRelativeStats() bool     { return true }
+func (m *mockTabler) SetFilter(pattern string)    { m.filter = pattern }
+func (m *mockTabler) Filter() string              { return m.filter }
+
+func newTestTabler(layout Layout) (*Dashboard, *Tabler, []*mockTabler) {
+	primary := &mockTabler{rows: []string{"3|a", "1|b"}}
+	replica := &mockTabler{rows: []string{"2|a"}}
+	d := NewDashboard([]Server{
+		{Name: "primary"},
+		{Name: "broken", Err: errors.New("connection refused")},
+		{Name: "replica"},
+	}, layout)
+	return d, d.NewTabler([]pstable.Tabler{primary, nil, replica}), []*mockTabler{primary, replica}
+}
+
+func TestStacked(t *testing.T) {
+	d, tabler, _ := newTestTabler(Stacked)
+
+	want := []string{
+		"[primary] Mock",
+		"3|a",
+		"1|b",
+		"[broken] error: connection refused",
+		"[replica] Mock",
+		"2|a",
+	}
+	if got := tabler.RowContent(); !slices.Equal(got, want) {
+		t.Errorf("RowContent() = %q, want %q", got, want)
+	}
+
+	// 6 rows shared by 3 servers leaves one row for each server's data
+	d.SetRows(6)
+	want = []string{
+		"[primary] Mock",
+		"3|a",
+		"[broken] error: connection refused",
+		"[replica] Mock",
+		"2|a",
+	}
+	if got := tabler.RowContent(); !slices.Equal(got, want) {
+		t.Errorf("RowContent() with 6 rows = %q, want %q", got, want)
+	}
+}
+
+func TestMerged(t *testing.T) {
+	d, tabler, _ := newTestTabler(Merged)
+
+	if got, want := tabler.Headings(), "Server  Value|Name"; got != want {
+		t.Errorf("Headings() = %q, want %q", got, want)
+	}
+
+	// mock tablers can not be merged so the rows are shown server by server
+	want := []string{
+		"broken  error: connection refused",
+		"primary 3|a",
+		"primary 1|b",
+		"replica 2|a",
+	}
+	if got := tabler.RowContent(); !slices.Equal(got, want) {
+		t.Errorf("RowContent() = %q, want %q", got, want)
+	}
+
+	d.ToggleLayout()
+	if d.Layout() != Stacked {
+		t.Errorf("ToggleLayout() changed to %v, want %v", d.Layout(), Stacked)
+	}
+}
+
+func TestCollectAndFilter(t *testing.T) {
+	_, tabler, mocks := newTestTabler(Stacked)
+
+	tabler.Collect()
+	tabler.SetFilter("a")
+	for i, m := range mocks {
+		if m.collected != 1 {
+			t.Errorf("server %d collected %d times, want 1", i, m.collected)
+		}
+		if m.filter != "a" {
+			t.Errorf("server %d filter %q, want %q", i, m.filter, "a")
+		}
+	}
+	if got := tabler.Filter(); got != "a" {
+		t.Errorf("Filter() = %q, want %q", got, "a")
+	}
+}
+
+func TestCollectFailure(t *testing.T) {
+	d, tabler, mocks := newTestTabler(Stacked)
+
+	// the replica going away must not stop collection from the primary
+	mocks[1].fatal = "invalid connection"
+	tabler.Collect()
+	tabler.Collect()
+
+	if mocks[0].collected != 2 {
+		t.Errorf("primary collected %d times, want 2", mocks[0].collected)
+	}
+	if err := d.Servers()[2].Err; err == nil || err.Error() != "invalid connection" {
+		t.Errorf("replica error = %v, want %q", err, "invalid connection")
+	}
+	want := []string{
+		"[primary] Mock",
+		"3|a",
+		"1|b",
+		"[broken] error: connection refused",
+		"[replica] error: invalid connection",
+	}
+	if got := tabler.RowContent(); !slices.Equal(got, want) {
+		t.Errorf("RowContent() = %q, want %q", got, want)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Layout
+		wantErr bool
+	}{
+		{"stacked", Stacked, false},
+		{"merged", Merged, false},
+		{"sideways", Stacked, true},
+	}
+	for _, test := range tests {
+		got, err := ParseLayout(test.name)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("ParseLayout(%q) = %v, %v, want %v, error: %v", test.name, got, err, test.want, test.wantErr)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/sjmudd/ps-top/log"
+)
+
+// batchWidth is the notional line width used when right aligning the
+// [REL]/[ABS] indicator on the top line as there is no terminal to size.
+const batchWidth = 132
+
+// Batch writes the wanted view as plain text to an io.Writer.  It is
+// used when no terminal is available, e.g. when piping output into
+// another program, in a similar way to top -b.
+type Batch struct {
+	config Config
+	out    io.Writer
+}
+
+// NewBatch returns a Batch which writes its output to out
+func NewBatch(config Config, out io.Writer) *Batch {
+	return &Batch{
+		config: config,
+		out:    out,
+	}
+}
+
+// Display writes the wanted view to the output followed by an empty line.
+// Rows with no content are not shown.
+func (batch *Batch) Display(gd GenericData) {
+	lines := []string{
+		topLine(
+			batch.config,
+			batch.config.Uptime(),
+			gd.HaveRelativeStats(),
+			batch.config.WantRelativeStats(),
+			gd.FirstCollectTime(),
+			gd.LastCollectTime(),
+			batchWidth,
+		),
+		gd.Description(),
+		gd.Headings(),
+	}
+
+	emptyRow := gd.EmptyRowContent()
+	for _, row := range gd.RowContent() {
+		if row != emptyRow {
+			lines = append(lines, row)
+		}
+	}
+	lines = append(lines, gd.TotalRowContent(), "")
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(batch.out, line); err != nil {
+			log.Printf("Batch.Display: write failed: %v", err)
+			return
+		}
+	}
+}
+
+// Clear does nothing as there is no screen to clear.
+func (batch *Batch) Clear() {}
@@ -0,0 +1,60 @@
+package display
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockConfig provides fixed values for the Config interface.
+type mockConfig struct{}
+
+func (mockConfig) Hostname() string        { return "myhost" }
+func (mockConfig) Port() string            { return "3306" }
+func (mockConfig) BindAddress() string     { return "*" }
+func (mockConfig) MySQLVersion() string    { return "8.4.0" }
+func (mockConfig) WantRelativeStats() bool { return true }
+func (mockConfig) Uptime() int             { return 90 }
+
+// mockData provides fixed content for the GenericData interface.
+type mockData struct{}
+
+func (mockData) Description() string         { return "Some Description 2 rows" }
+func (mockData) Headings() string            { return "Latency|Name" }
+func (mockData) FirstCollectTime() time.Time { return time.Now() }
+func (mockData) LastCollectTime() time.Time  { return time.Now() }
+func (mockData) RowContent() []string        { return []string{"   10 ms|t1", "       |", "    5 ms|t2"} }
+func (mockData) TotalRowContent() string     { return "   15 ms|Totals" }
+func (mockData) EmptyRowContent() string     { return "       |" }
+func (mockData) HaveRelativeStats() bool     { return true }
+
+// TestBatchDisplay checks the plain text output skips empty rows and
+// includes the top line, description, headings and totals.
+func TestBatchDisplay(t *testing.T) {
+	var buf bytes.Buffer
+
+	NewBatch(mockConfig{}, &buf).Display(mockData{})
+
+	lines := strings.Split(buf.String(), "\n")
+	expected := []string{
+		"Some Description 2 rows",
+		"Latency|Name",
+		"   10 ms|t1",
+		"    5 ms|t2",
+		"   15 ms|Totals",
+		"",
+		"",
+	}
+	if len(lines) != len(expected)+1 {
+		t.Fatalf("Display() returned %d lines, expected %d: %q", len(lines), len(expected)+1, lines)
+	}
+	if !strings.Contains(lines[0], "myhost:3306 / 8.4.0, up 1m 30s") || !strings.Contains(lines[0], "[REL]") {
+		t.Errorf("unexpected top line: %q", lines[0])
+	}
+	for i := range expected {
+		if lines[i+1] != expected[i] {
+			t.Errorf("line %d: expected %q, got %q", i+1, expected[i], lines[i+1])
+		}
+	}
+}
@@ -20,7 +20,10 @@ var (
 	topLineStyle      = tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorGrey)
 	descriptionStyle  = tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorTeal)
 	headingStyle      = tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack)
+	sortHeadingStyle  = headingStyle.Reverse(true)
+	reverseSortStyle  = sortHeadingStyle.Underline(true)
 	tableStyle        = tcell.StyleDefault.Foreground(tcell.ColorGrey).Background(tcell.ColorBlack)
+	selectedStyle     = tableStyle.Reverse(true)
 	menuStyle         = tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorGrey)
 	menuTextStyle     = tcell.StyleDefault.Foreground(tcell.ColorDarkRed).Background(tcell.ColorGrey)
 	bracketStyle      = tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorGrey)
@@ -43,8 +46,10 @@ type Display struct {
 	config    Config
 	screen    tcell.Screen
 	tcellChan chan tcell.Event
-	height    int // display height
-	width     int // display width
+	prompt    prompt // line of input being read from the user
+	offset    int    // index of the first row shown when scrolling
+	height    int    // display height
+	width     int    // display width
 }
 
 // NewDisplay returns a Display with an empty terminal
@@ -102,12 +107,53 @@ func (display *Display) printLine(y int, text string, style tcell.Style) {
 	}
 }
 
-// printTableData displays the provided content, filling lines with an empty row if needed
-func (display *Display) printTableData(content []string, lastRow, maxRows int, emptyRow string, style tcell.Style) {
+// highlight redraws the first occurrence of heading in the text
+// already printed on the given line using the given style
+func (display *Display) highlight(y int, text, heading string, style tcell.Style) {
+	if heading == "" {
+		return
+	}
+	index := strings.Index(text, heading)
+	if index < 0 {
+		return
+	}
+
+	x := len([]rune(text[:index]))
+	for _, r := range heading {
+		if x < display.width {
+			display.screen.SetContent(x, y, r, nil, style)
+			x++
+		}
+	}
+}
+
+// scrollOffset returns the index of the first of rows to show so that
+// the selected row (if any) is visible, changing the offset as little
+// as possible and showing as many rows as possible.
+func scrollOffset(offset, selected, rows, visible int) int {
+	if selected >= 0 {
+		if selected < offset {
+			offset = selected
+		}
+		if selected >= offset+visible {
+			offset = selected - visible + 1
+		}
+	}
+	offset = min(offset, rows-visible)
+	return max(offset, 0)
+}
+
+// printTableData displays the provided content, filling lines with an empty row if needed.
+// The row at index selected (if any) is highlighted.
+func (display *Display) printTableData(content []string, lastRow, maxRows int, emptyRow string, style tcell.Style, selected int) {
 	for k := 0; k < maxRows; k++ {
 		y := 3 + k
 		if k <= len(content)-1 && k < maxRows {
-			display.printLine(y, content[k], style)
+			rowStyle := style
+			if k == selected {
+				rowStyle = selectedStyle
+			}
+			display.printLine(y, content[k], rowStyle)
 		} else if y < lastRow {
 			display.printLine(y, emptyRow, style)
 		}
@@ -118,7 +164,7 @@ func (display *Display) printTableData(content []string, lastRow, maxRows int, e
 // - styling - normally inverted style (black on grey), except between [ ] where we use tcell.ColorBlue
 func (display *Display) printMenu(bottomRow int) {
 	const (
-		menu         = "[+-] Delay  [<] Prev  [>] Next  [h]elp  [r] Abs/Rel  [q]uit  [z] Reset stats"
+		menu         = "[+-] Delay  [<] Prev  [>] Next  [h]elp  [r] Abs/Rel  [s]ort  [/] Filter  [q]uit  [z] Reset stats"
 		openBracket  = rune('[')
 		closeBracket = rune(']')
 	)
@@ -151,6 +197,7 @@ func (display *Display) printMenu(bottomRow int) {
 
 // Clear clears the screen and flushes out the result to the terminal
 func (display *Display) Clear() {
+	display.offset = 0
 	display.screen.Clear()
 	display.screen.Sync()
 }
@@ -172,14 +219,34 @@ func (display *Display) Display(gd GenericData) {
 		topLineStyle)
 	display.printLine(1, gd.Description(), descriptionStyle) // display table description
 	display.printLine(2, gd.Headings(), headingStyle)
+	if sortable, ok := gd.(Sortable); ok {
+		style := sortHeadingStyle
+		if sortable.SortReversed() {
+			style = reverseSortStyle
+		}
+		display.highlight(2, gd.Headings(), sortable.SortHeading(), style)
+	}
 	// display table headings, data and totals
-	display.printTableData(gd.RowContent(), lastRow, maxRows, gd.EmptyRowContent(), tableStyle)
+	content := gd.RowContent()
+	selected := -1
+	if selectable, ok := gd.(Selectable); ok {
+		selected = selectable.SelectedRow()
+	}
+	display.offset = scrollOffset(display.offset, selected, len(content), maxRows)
+	display.printTableData(content[display.offset:], lastRow, maxRows, gd.EmptyRowContent(), tableStyle, selected-display.offset)
 	display.printLine(lastRow, gd.TotalRowContent(), defaultStyle)
-	display.printMenu(bottomRow)
+	if !display.printPrompt(bottomRow) {
+		display.printMenu(bottomRow)
+	}
 
 	display.screen.Show()
 }
 
+// TableRows returns the number of table rows which can be shown on the screen
+func (display *Display) TableRows() int {
+	return max(display.height-5, 1)
+}
+
 // Resize records the new size of the screen and clears it
 func (display *Display) Resize(width, height int) {
 	log.Printf("Display.Resize(width: %v, height: %v), previous values: (width: %v, height: %v)", width, height, display.width, display.height)
@@ -203,6 +270,9 @@ func (display *Display) poll() event.Event {
 	switch evt := tcellEvent.(type) {
 	case *tcell.EventKey:
 		log.Printf("tcell.EventKey: %+v", evt)
+		if display.prompt.isActive() && evt.Key() != tcell.KeyCtrlC {
+			return display.promptKey(evt)
+		}
 		switch evt.Key() {
 		case tcell.KeyCtrlZ, tcell.KeyCtrlC, tcell.KeyEsc:
 			e = event.Event{Type: event.EventFinished}
@@ -210,18 +280,49 @@ func (display *Display) poll() event.Event {
 			e = event.Event{Type: event.EventViewPrev}
 		case tcell.KeyTab, tcell.KeyRight:
 			e = event.Event{Type: event.EventViewNext}
+		case tcell.KeyEnter:
+			e = event.Event{Type: event.EventDetail}
+		case tcell.KeyUp:
+			e = event.Event{Type: event.EventRowUp}
+		case tcell.KeyDown:
+			e = event.Event{Type: event.EventRowDown}
+		case tcell.KeyPgUp:
+			e = event.Event{Type: event.EventPageUp}
+		case tcell.KeyPgDn:
+			e = event.Event{Type: event.EventPageDown}
+		case tcell.KeyHome:
+			e = event.Event{Type: event.EventFirstRow}
+		case tcell.KeyEnd:
+			e = event.Event{Type: event.EventLastRow}
 		case tcell.KeyRune:
 			switch evt.Rune() {
+			case '/':
+				display.startPrompt(filterPrompt)
+				e = event.Event{Type: event.EventNone}
 			case '-':
 				e = event.Event{Type: event.EventDecreasePollTime}
 			case '+':
 				e = event.Event{Type: event.EventIncreasePollTime}
+			case 'c':
+				e = event.Event{Type: event.EventFilterClear}
+			case 'L':
+				e = event.Event{Type: event.EventDashboardLayout}
+			case 'g':
+				e = event.Event{Type: event.EventGroupNext}
 			case 'h', '?':
 				e = event.Event{Type: event.EventHelp}
+			case 'n':
+				e = event.Event{Type: event.EventReplayNext}
+			case 'p':
+				e = event.Event{Type: event.EventReplayPrev}
 			case 'q':
 				e = event.Event{Type: event.EventFinished}
 			case 'r':
 				e = event.Event{Type: event.EventToggleWantRelative}
+			case 'R':
+				e = event.Event{Type: event.EventSortReverse}
+			case 's':
+				e = event.Event{Type: event.EventSortNext}
 			case 'z':
 				e = event.Event{Type: event.EventResetStatistics}
 			}
@@ -255,24 +356,39 @@ func (display *Display) EventChan() chan event.Event {
 }
 
 // generateTopLine returns the heading line as a string
-func (display *Display) generateTopLine(haveRelativeStats, wantRelativeStats bool, initial time.Time, _ time.Time, width int) string {
+func (display *Display) generateTopLine(haveRelativeStats, wantRelativeStats bool, initial, last time.Time, width int) string {
+	return topLine(display.config, display.uptime(), haveRelativeStats, wantRelativeStats, initial, last, width)
+}
+
+// topLine returns the heading line shared by the screen and batch output.
+// The relative/absolute indicator is right aligned to the given width.
+// The time shown is that of the last collection so that replayed data
+// shows when it was recorded.
+func topLine(config Config, up int, haveRelativeStats, wantRelativeStats bool, initial, last time.Time, width int) string {
+	if last.IsZero() {
+		last = time.Now()
+	}
+
 	// Determine what to display: if bind_address is not "*", show it; otherwise show hostname
-	hostOrBind := display.config.Hostname()
-	if bindAddr := display.config.BindAddress(); bindAddr != "*" {
+	hostOrBind := config.Hostname()
+	if bindAddr := config.BindAddress(); bindAddr != "*" {
 		hostOrBind = bindAddr
 	}
-	hostWithPort := hostOrBind + ":" + display.config.Port()
+	hostWithPort := hostOrBind
+	if port := config.Port(); port != "" {
+		hostWithPort += ":" + port
+	}
 	heading := utils.ProgName + " " +
 		utils.Version + " - " +
-		now() + " " +
+		clock(last) + " " +
 		hostWithPort + " / " +
-		display.config.MySQLVersion() + ", up " +
-		fmt.Sprintf("%-16s", uptime(display.uptime()))
+		config.MySQLVersion() + ", up " +
+		fmt.Sprintf("%-16s", uptime(up))
 
 	if haveRelativeStats {
 		var suffix string
 		if wantRelativeStats {
-			suffix = " [REL] " + fmt.Sprintf("%.0f seconds", time.Since(initial).Seconds())
+			suffix = " [REL] " + fmt.Sprintf("%.0f seconds", last.Sub(initial).Seconds())
 		} else {
 			suffix = " [ABS]             "
 		}
@@ -283,9 +399,8 @@ func (display *Display) generateTopLine(haveRelativeStats, wantRelativeStats boo
 	return heading
 }
 
-// now returns the current time in format hh:mm:ss
-func now() string {
-	t := time.Now()
+// clock returns the given time in format hh:mm:ss
+func clock(t time.Time) string {
 	return fmt.Sprintf("%2d:%02d:%02d", t.Hour(), t.Minute(), t.Second())
 }
 
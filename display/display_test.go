@@ -0,0 +1,31 @@
+package display
+
+import (
+	"testing"
+)
+
+// TestScrollOffset checks the selected row is kept visible while
+// scrolling as little as possible.
+func TestScrollOffset(t *testing.T) {
+	tests := []struct {
+		name                            string
+		offset, selected, rows, visible int
+		expected                        int
+	}{
+		{"no selection, few rows", 0, -1, 5, 10, 0},
+		{"no selection keeps offset", 3, -1, 50, 10, 3},
+		{"selection visible", 0, 4, 50, 10, 0},
+		{"selection below", 0, 10, 50, 10, 1},
+		{"selection far below", 0, 35, 50, 10, 26},
+		{"selection above", 20, 5, 50, 10, 5},
+		{"offset past the end", 45, -1, 50, 10, 40},
+		{"rows removed", 30, -1, 8, 10, 0},
+	}
+	for _, test := range tests {
+		got := scrollOffset(test.offset, test.selected, test.rows, test.visible)
+		if got != test.expected {
+			t.Errorf("%s: scrollOffset(%d, %d, %d, %d) = %d, expected %d",
+				test.name, test.offset, test.selected, test.rows, test.visible, got, test.expected)
+		}
+	}
+}
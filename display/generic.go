@@ -15,3 +15,14 @@ type GenericData interface {
 	EmptyRowContent() string     // a string containing the details of an empty row
 	HaveRelativeStats() bool     // does this data type have relative statistics
 }
+
+// Selectable is implemented by data which has a selected row
+type Selectable interface {
+	SelectedRow() int // index of the selected row in RowContent(), -1 if none
+}
+
+// Sortable is implemented by data whose rows can be sorted on different columns
+type Sortable interface {
+	SortHeading() string // heading of the column sorted on (highlighted)
+	SortReversed() bool  // is the sort order reversed?
+}
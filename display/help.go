@@ -23,14 +23,32 @@ func (h HelpType) RowContent() []string {
 		"Keys:",
 		"   - - reduce the poll interval by 1 second (minimum 1 second)",
 		"   + - increase the poll interval by 1 second",
+		"   / - only show rows whose names match a pattern (regular expression or text)",
+		"   c - clear the row filter",
+		"   g - group the rows differently (where enabled), e.g. mutexes by type or memory by host",
 		"   h/? - this help screen",
+		"   L - change between the stacked and merged layouts when showing several servers",
+		"   n - step to the next collection when replaying a recording",
+		"   p - step to the previous collection when replaying a recording",
 		"   q - quit",
+		"   R - reverse the sort order",
 		"   s - sort differently (where enabled) - sorts on a different column",
 		"   t - toggle between showing time since resetting statistics or since P_S data was collected",
 		"   z - reset statistics",
-		"   <tab> or <right arrow> - change display modes between: latency, ops,",
-		"                            file I/O, lock and user modes",
+		"   <tab> or <right arrow> - change to the next display mode: latency, ops,",
+		"                            file I/O, lock, user, mutex, stages, memory,",
+		"                            statement digest, running statement, lock wait,",
+		"                            metadata lock, replication, index usage,",
+		"                            unused index, account activity, host",
+		"                            activity, global status, transaction,",
+		"                            statement histogram, stage progress, file",
+		"                            I/O category, file I/O event, socket I/O",
+		"                            type, socket I/O address and memory by",
+		"                            owner modes",
 		"   <left arrow> - change display modes to the previous screen (see above)",
+		"   <up/down arrow>, <page up/down>, <home>, <end> - select a row, scrolling if needed",
+		"   <enter> - show every value of the selected row (and for tables the file I/O",
+		"             and lock information of the same table), <enter> again returns",
 		"",
 		"Press h to return to main screen",
 	}
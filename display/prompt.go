@@ -0,0 +1,92 @@
+package display
+
+import (
+	"sync"
+
+	tcell "github.com/gdamore/tcell/v2"
+
+	"github.com/sjmudd/ps-top/event"
+)
+
+// filterPrompt is shown when asking for the pattern to filter rows with
+const filterPrompt = "Filter rows matching (empty shows all, Esc cancels): "
+
+// prompt holds the line of input being read from the user.
+// Keys are read in the poller goroutine while the screen is also
+// redrawn from the application so access is protected by a mutex.
+type prompt struct {
+	mu     sync.Mutex
+	active bool   // are we reading input?
+	label  string // text shown before the input
+	input  []rune // input so far
+}
+
+// isActive returns true if we are reading input
+func (p *prompt) isActive() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.active
+}
+
+// startPrompt starts reading a line of input on the bottom row
+func (display *Display) startPrompt(label string) {
+	display.prompt.mu.Lock()
+	display.prompt.active = true
+	display.prompt.label = label
+	display.prompt.input = nil
+	display.prompt.mu.Unlock()
+
+	display.printPrompt(display.height - 1)
+	display.screen.Show()
+}
+
+// promptKey handles a key while reading input. Once Enter is pressed
+// an EventFilter event is returned with the input. Escape cancels the
+// input and restores the menu. EventNone is returned otherwise.
+func (display *Display) promptKey(evt *tcell.EventKey) event.Event {
+	e := event.Event{Type: event.EventNone}
+
+	display.prompt.mu.Lock()
+	switch evt.Key() {
+	case tcell.KeyEnter:
+		display.prompt.active = false
+		e = event.Event{Type: event.EventFilter, Text: string(display.prompt.input)}
+	case tcell.KeyEsc:
+		display.prompt.active = false
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if n := len(display.prompt.input); n > 0 {
+			display.prompt.input = display.prompt.input[:n-1]
+		}
+	case tcell.KeyCtrlU:
+		display.prompt.input = nil
+	case tcell.KeyRune:
+		display.prompt.input = append(display.prompt.input, evt.Rune())
+	}
+	display.prompt.mu.Unlock()
+
+	bottomRow := display.height - 1
+	if !display.printPrompt(bottomRow) {
+		display.screen.HideCursor()
+		display.printMenu(bottomRow)
+	}
+	display.screen.Show()
+
+	return e
+}
+
+// printPrompt shows the input being read on the given row with the
+// cursor after it. It returns false if we are not reading input.
+func (display *Display) printPrompt(row int) bool {
+	display.prompt.mu.Lock()
+	defer display.prompt.mu.Unlock()
+
+	if !display.prompt.active {
+		return false
+	}
+
+	text := display.prompt.label + string(display.prompt.input)
+	display.printLine(row, text, menuStyle)
+	display.screen.ShowCursor(len([]rune(text)), row)
+
+	return true
+}
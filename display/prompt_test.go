@@ -0,0 +1,66 @@
+package display
+
+import (
+	"testing"
+
+	tcell "github.com/gdamore/tcell/v2"
+
+	"github.com/sjmudd/ps-top/event"
+)
+
+// newTestDisplay returns a Display using a simulated screen.
+func newTestDisplay(t *testing.T) *Display {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("screen.Init() failed: %v", err)
+	}
+	t.Cleanup(screen.Fini)
+	screen.SetSize(80, 25)
+	return &Display{screen: screen, width: 80, height: 25}
+}
+
+func runeKey(r rune) *tcell.EventKey {
+	return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+}
+
+func key(k tcell.Key) *tcell.EventKey {
+	return tcell.NewEventKey(k, 0, tcell.ModNone)
+}
+
+// TestPromptEnter checks the input typed is returned when Enter is pressed.
+func TestPromptEnter(t *testing.T) {
+	d := newTestDisplay(t)
+	d.startPrompt(filterPrompt)
+
+	for _, k := range []*tcell.EventKey{runeKey('s'), runeKey('x'), key(tcell.KeyBackspace2), runeKey('h'), runeKey('o'), runeKey('p')} {
+		if e := d.promptKey(k); e.Type != event.EventNone {
+			t.Fatalf("expected EventNone while typing, got %v", e.Type)
+		}
+	}
+	if !d.prompt.isActive() {
+		t.Fatal("prompt should still be active")
+	}
+
+	e := d.promptKey(key(tcell.KeyEnter))
+	if e.Type != event.EventFilter || e.Text != "shop" {
+		t.Errorf("expected EventFilter with text %q, got %+v", "shop", e)
+	}
+	if d.prompt.isActive() {
+		t.Error("prompt should not be active after Enter")
+	}
+}
+
+// TestPromptEscape checks Escape cancels the input without an event.
+func TestPromptEscape(t *testing.T) {
+	d := newTestDisplay(t)
+	d.startPrompt(filterPrompt)
+	d.promptKey(runeKey('a'))
+
+	if e := d.promptKey(key(tcell.KeyEsc)); e.Type != event.EventNone {
+		t.Errorf("expected EventNone after Escape, got %+v", e)
+	}
+	if d.prompt.isActive() {
+		t.Error("prompt should not be active after Escape")
+	}
+}
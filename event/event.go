@@ -16,14 +16,31 @@ const (
 	EventHelp                           // provide me with help
 	EventToggleWantRelative             // toggle between wanting absolute or relative stats
 	EventResetStatistics                // reset the current stats back to zero
+	EventReplayNext                     // step to the next recorded collection
+	EventReplayPrev                     // step to the previous recorded collection
+	EventSortNext                       // sort on the next column
+	EventSortReverse                    // reverse the sort order
+	EventGroupNext                      // group the rows in the next way
+	EventFilter                         // filter rows using the text provided
+	EventFilterClear                    // stop filtering rows
+	EventRowUp                          // select the previous row
+	EventRowDown                        // select the next row
+	EventPageUp                         // select the row a screen above
+	EventPageDown                       // select the row a screen below
+	EventFirstRow                       // select the first row
+	EventLastRow                        // select the last row
+	EventDetail                         // toggle showing the detail of the selected row
+	EventDashboardLayout                // change how the servers of a dashboard are shown
 	EventResizeScreen                   // not really a event but a state change
 	EventUnknown                        // something weird has happened
 	EventError                          // some error
 )
 
 // Event is one of the earlier list of Event constants and also contains a position
+// or the text entered by the user
 type Event struct {
 	Type   Type
 	Width  int
 	Height int
+	Text   string
 }
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Table is implemented by tablers which declare a column schema for
+// their raw (unformatted) row data.
+type Table interface {
+	Data
+	ColumnNames() []string    // names of the raw data columns
+	ColumnValues() [][]string // raw data values of each result row
+}
+
+// CSV writes a header row followed by one line per result row for each collection.
+// Each line is prefixed by the collection times and the relative flag.
+type CSV struct {
+	writer *csv.Writer
+	view   string // view of the last header row written
+}
+
+// NewCSV returns a CSV writer which writes to out
+func NewCSV(out io.Writer) *CSV {
+	return &CSV{writer: csv.NewWriter(out)}
+}
+
+// Write writes the rows of the given view, preceded by a header row
+// the first time the view is written.
+func (c *CSV) Write(view string, data Data) error {
+	table, ok := data.(Table)
+	if !ok {
+		return fmt.Errorf("CSV.Write: view %s does not provide a column schema", view)
+	}
+
+	if view != c.view {
+		header := append([]string{"first_collected", "last_collected", "relative"}, table.ColumnNames()...)
+		if err := c.writer.Write(header); err != nil {
+			return err
+		}
+		c.view = view
+	}
+
+	prefix := []string{
+		data.FirstCollectTime().Format(time.RFC3339Nano),
+		data.LastCollectTime().Format(time.RFC3339Nano),
+		strconv.FormatBool(relative(data)),
+	}
+	for _, values := range table.ColumnValues() {
+		if err := c.writer.Write(append(append([]string{}, prefix...), values...)); err != nil {
+			return err
+		}
+	}
+
+	c.writer.Flush()
+	return c.writer.Error()
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockTable adds a column schema to mockData.
+type mockTable struct {
+	mockData
+}
+
+func (m mockTable) ColumnNames() []string { return []string{"name", "sum_timer_wait"} }
+func (m mockTable) ColumnValues() [][]string {
+	values := make([][]string, 0, len(m.rows))
+	for _, r := range m.rows {
+		values = append(values, []string{r.Name, "12345678901234"})
+	}
+	return values
+}
+
+// TestCSVWrite checks the header is only written once per view and
+// each result row is written as a line with the collection prefix.
+func TestCSVWrite(t *testing.T) {
+	var buf bytes.Buffer
+	first := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := mockTable{mockData{
+		first:   first,
+		last:    first.Add(time.Second),
+		haveRel: true,
+		wantRel: true,
+		rows:    []testRow{{"db.t1", 1}, {"db,t2", 2}},
+	}}
+
+	w := NewCSV(&buf)
+	for i := 0; i < 2; i++ {
+		if err := w.Write("table_io_latency", data); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+
+	expected := []string{
+		"first_collected,last_collected,relative,name,sum_timer_wait",
+		"2024-01-02T03:04:05Z,2024-01-02T03:04:06Z,true,db.t1,12345678901234",
+		`2024-01-02T03:04:05Z,2024-01-02T03:04:06Z,true,"db,t2",12345678901234`,
+		"2024-01-02T03:04:05Z,2024-01-02T03:04:06Z,true,db.t1,12345678901234",
+		`2024-01-02T03:04:05Z,2024-01-02T03:04:06Z,true,"db,t2",12345678901234`,
+	}
+	got := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected CSV output:\n got: %q\nwant: %q", got, expected)
+	}
+}
+
+// TestCSVWriteNoSchema checks an error is returned if there is no column schema.
+func TestCSVWriteNoSchema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewCSV(&buf).Write("some_view", mockData{}); err == nil {
+		t.Error("expected an error writing data without a column schema")
+	}
+}
@@ -0,0 +1,53 @@
+// Package export writes the collected data in machine readable formats
+// so that it can be processed by other programs.
+package export
+
+import (
+	"fmt"
+	"time"
+)
+
+// Format indicates how batch output should be written
+type Format int
+
+// Format* constants represent the supported output formats
+const (
+	FormatText Format = iota // plain text as seen on the screen
+	FormatJSON               // one JSON record per line (NDJSON)
+	FormatCSV                // comma separated values with a header row
+)
+
+var formatNames = map[string]Format{
+	"text": FormatText,
+	"json": FormatJSON,
+	"csv":  FormatCSV,
+}
+
+// ParseFormat returns the Format for the given name or an error if not known
+func ParseFormat(name string) (Format, error) {
+	if format, ok := formatNames[name]; ok {
+		return format, nil
+	}
+	return FormatText, fmt.Errorf("unknown output format %q, expected one of: text, json, csv", name)
+}
+
+// Data is implemented by tablers which can provide their rows in their
+// native types rather than as formatted strings.
+type Data interface {
+	FirstCollectTime() time.Time // time of first collection
+	LastCollectTime() time.Time  // time of last collection
+	HaveRelativeStats() bool     // do we have relative stats in the provided data?
+	WantRelativeStats() bool     // do we want relative stats?
+	RawResults() any             // slice of result rows
+	RawTotals() any              // totals row
+}
+
+// Writer writes the data of the named view to some output
+type Writer interface {
+	Write(view string, data Data) error
+}
+
+// relative returns true if the values in data are relative to the baseline
+func relative(data Data) bool {
+	return data.HaveRelativeStats() && data.WantRelativeStats()
+}
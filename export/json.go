@@ -0,0 +1,40 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// record is the JSON representation of one collection of a view
+type record struct {
+	View           string    `json:"view"`
+	FirstCollected time.Time `json:"first_collected"`
+	LastCollected  time.Time `json:"last_collected"`
+	Relative       bool      `json:"relative"`
+	Rows           any       `json:"rows"`
+	Totals         any       `json:"totals"`
+}
+
+// JSON writes each collection as a single line JSON record (NDJSON).
+// Row fields use the names of the model's row type, e.g. SumTimerFetch.
+type JSON struct {
+	encoder *json.Encoder
+}
+
+// NewJSON returns a JSON writer which writes to out
+func NewJSON(out io.Writer) *JSON {
+	return &JSON{encoder: json.NewEncoder(out)}
+}
+
+// Write writes one record for the given view
+func (j *JSON) Write(view string, data Data) error {
+	return j.encoder.Encode(record{
+		View:           view,
+		FirstCollected: data.FirstCollectTime(),
+		LastCollected:  data.LastCollectTime(),
+		Relative:       relative(data),
+		Rows:           data.RawResults(),
+		Totals:         data.RawTotals(),
+	})
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type testRow struct {
+	Name         string
+	SumTimerWait uint64
+}
+
+// mockData is a minimal implementation of Data for testing.
+type mockData struct {
+	first, last time.Time
+	haveRel     bool
+	wantRel     bool
+	rows        []testRow
+	totals      testRow
+}
+
+func (m mockData) FirstCollectTime() time.Time { return m.first }
+func (m mockData) LastCollectTime() time.Time  { return m.last }
+func (m mockData) HaveRelativeStats() bool     { return m.haveRel }
+func (m mockData) WantRelativeStats() bool     { return m.wantRel }
+func (m mockData) RawResults() any             { return m.rows }
+func (m mockData) RawTotals() any              { return m.totals }
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Format
+		wantErr  bool
+	}{
+		{"text", FormatText, false},
+		{"json", FormatJSON, false},
+		{"csv", FormatCSV, false},
+		{"xml", FormatText, true},
+		{"", FormatText, true},
+	}
+	for _, test := range tests {
+		got, err := ParseFormat(test.name)
+		if got != test.expected || (err != nil) != test.wantErr {
+			t.Errorf("ParseFormat(%q) returned (%v, %v), expected (%v, error: %v)", test.name, got, err, test.expected, test.wantErr)
+		}
+	}
+}
+
+// TestJSONWrite checks one record per line is written with raw row fields.
+func TestJSONWrite(t *testing.T) {
+	var buf bytes.Buffer
+	first := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := mockData{
+		first:   first,
+		last:    first.Add(10 * time.Second),
+		haveRel: true,
+		wantRel: true,
+		rows:    []testRow{{"db.t1", 100}, {"db.t2", 50}},
+		totals:  testRow{"Totals", 150},
+	}
+
+	w := NewJSON(&buf)
+	if err := w.Write("table_io_latency", data); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	data.wantRel = false
+	if err := w.Write("table_io_latency", data); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	lines := bytes.Split(bytes.TrimRight(buf.Bytes(), "\n"), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var got struct {
+		View          string    `json:"view"`
+		LastCollected time.Time `json:"last_collected"`
+		Relative      bool      `json:"relative"`
+		Rows          []testRow `json:"rows"`
+		Totals        testRow   `json:"totals"`
+	}
+	if err := json.Unmarshal(lines[0], &got); err != nil {
+		t.Fatalf("failed to decode %q: %v", lines[0], err)
+	}
+	if got.View != "table_io_latency" || !got.Relative || !got.LastCollected.Equal(data.last) {
+		t.Errorf("unexpected record header: %+v", got)
+	}
+	if len(got.Rows) != 2 || got.Rows[1].SumTimerWait != 50 || got.Totals.SumTimerWait != 150 {
+		t.Errorf("unexpected record data: %+v", got)
+	}
+
+	if err := json.Unmarshal(lines[1], &got); err != nil {
+		t.Fatalf("failed to decode %q: %v", lines[1], err)
+	}
+	if got.Relative {
+		t.Errorf("expected second record to be absolute: %s", lines[1])
+	}
+}
@@ -0,0 +1,195 @@
+package export
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sjmudd/ps-top/model/fileinfo"
+	"github.com/sjmudd/ps-top/model/memoryusage"
+	"github.com/sjmudd/ps-top/model/mutexlatency"
+	"github.com/sjmudd/ps-top/model/stageslatency"
+	"github.com/sjmudd/ps-top/model/tableio"
+	"github.com/sjmudd/ps-top/model/userlatency"
+)
+
+const (
+	metricPrefix      = "pstop_" // prefix of all metric names
+	picosecondsPerSec = 1e12
+	prometheusCounter = "counter"
+	prometheusGauge   = "gauge"
+)
+
+var errRelativeData = errors.New("relative data can not be exported as metrics")
+
+// PrometheusContentType is the content type of the Prometheus text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// sample is a single labelled value of a metric
+type sample struct {
+	labels []string // label name, value pairs
+	value  float64
+}
+
+// family holds all the samples of a single metric
+type family struct {
+	name    string
+	help    string
+	kind    string
+	samples []sample
+}
+
+func (f *family) add(value float64, labels ...string) {
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// seconds converts picoseconds to seconds, the Prometheus base unit of time
+func seconds(picoseconds uint64) float64 {
+	return float64(picoseconds) / picosecondsPerSec
+}
+
+// WritePrometheus writes the absolute values of the given data in the
+// Prometheus text exposition format. Data whose rows are not
+// recognised is ignored. An error is returned if the data is relative
+// as Prometheus counters are expected to only increase.
+func WritePrometheus(out io.Writer, data ...Data) error {
+	var families []*family
+
+	for _, d := range data {
+		if relative(d) {
+			return errRelativeData
+		}
+		switch rows := d.RawResults().(type) {
+		case []tableio.Row:
+			families = append(families, tableIOFamilies(rows)...)
+		case []fileinfo.Row:
+			families = append(families, fileIOFamilies(rows)...)
+		case []mutexlatency.Row:
+			families = append(families, mutexFamilies(rows)...)
+		case []stageslatency.Row:
+			families = append(families, stageFamilies(rows)...)
+		case []memoryusage.Row:
+			families = append(families, memoryFamilies(rows)...)
+		case []userlatency.Row:
+			families = append(families, userFamilies(rows)...)
+		}
+	}
+
+	w := bufio.NewWriter(out)
+	for _, f := range families {
+		writeFamily(w, f)
+	}
+	return w.Flush()
+}
+
+func tableIOFamilies(rows []tableio.Row) []*family {
+	wait := &family{name: "table_io_wait_seconds_total", help: "Time waiting for table I/O by table and operation.", kind: prometheusCounter}
+	ops := &family{name: "table_io_operations_total", help: "Number of table I/O operations by table and operation.", kind: prometheusCounter}
+
+	for _, r := range rows {
+		wait.add(seconds(r.SumTimerFetch), "table", r.Name, "operation", "fetch")
+		wait.add(seconds(r.SumTimerInsert), "table", r.Name, "operation", "insert")
+		wait.add(seconds(r.SumTimerUpdate), "table", r.Name, "operation", "update")
+		wait.add(seconds(r.SumTimerDelete), "table", r.Name, "operation", "delete")
+		ops.add(float64(r.CountFetch), "table", r.Name, "operation", "fetch")
+		ops.add(float64(r.CountInsert), "table", r.Name, "operation", "insert")
+		ops.add(float64(r.CountUpdate), "table", r.Name, "operation", "update")
+		ops.add(float64(r.CountDelete), "table", r.Name, "operation", "delete")
+	}
+
+	return []*family{wait, ops}
+}
+
+func fileIOFamilies(rows []fileinfo.Row) []*family {
+	wait := &family{name: "file_io_wait_seconds_total", help: "Time waiting for file I/O by file and operation.", kind: prometheusCounter}
+	ops := &family{name: "file_io_operations_total", help: "Number of file I/O operations by file and operation.", kind: prometheusCounter}
+	bytes := &family{name: "file_io_bytes_total", help: "Number of bytes of file I/O by file and operation.", kind: prometheusCounter}
+
+	for _, r := range rows {
+		wait.add(seconds(r.SumTimerRead), "file", r.Name, "operation", "read")
+		wait.add(seconds(r.SumTimerWrite), "file", r.Name, "operation", "write")
+		wait.add(seconds(r.SumTimerMisc), "file", r.Name, "operation", "misc")
+		ops.add(float64(r.CountRead), "file", r.Name, "operation", "read")
+		ops.add(float64(r.CountWrite), "file", r.Name, "operation", "write")
+		ops.add(float64(r.CountMisc), "file", r.Name, "operation", "misc")
+		bytes.add(float64(r.SumNumberOfBytesRead), "file", r.Name, "operation", "read")
+		bytes.add(float64(r.SumNumberOfBytesWrite), "file", r.Name, "operation", "write")
+	}
+
+	return []*family{wait, ops, bytes}
+}
+
+func mutexFamilies(rows []mutexlatency.Row) []*family {
+	wait := &family{name: "mutex_wait_seconds_total", help: "Time waiting for mutexes and other synchronisation objects by name.", kind: prometheusCounter}
+	count := &family{name: "mutex_waits_total", help: "Number of waits for mutexes and other synchronisation objects by name.", kind: prometheusCounter}
+
+	for _, r := range rows {
+		wait.add(seconds(r.SumTimerWait), "mutex", r.Name)
+		count.add(float64(r.CountStar), "mutex", r.Name)
+	}
+
+	return []*family{wait, count}
+}
+
+func stageFamilies(rows []stageslatency.Row) []*family {
+	wait := &family{name: "stage_wait_seconds_total", help: "Time spent in SQL stages by stage name.", kind: prometheusCounter}
+	count := &family{name: "stages_total", help: "Number of SQL stages executed by stage name.", kind: prometheusCounter}
+
+	for _, r := range rows {
+		wait.add(seconds(r.SumTimerWait), "stage", r.Name)
+		count.add(float64(r.CountStar), "stage", r.Name)
+	}
+
+	return []*family{wait, count}
+}
+
+func memoryFamilies(rows []memoryusage.Row) []*family {
+	current := &family{name: "memory_current_bytes", help: "Memory currently used by memory event name.", kind: prometheusGauge}
+	high := &family{name: "memory_high_bytes", help: "Highest memory used by memory event name.", kind: prometheusGauge}
+
+	for _, r := range rows {
+		current.add(float64(r.CurrentBytesUsed), "event", r.Name)
+		high.add(float64(r.HighBytesUsed), "event", r.Name)
+	}
+
+	return []*family{current, high}
+}
+
+func userFamilies(rows []userlatency.Row) []*family {
+	connections := &family{name: "user_connections", help: "Number of connections by user.", kind: prometheusGauge}
+	active := &family{name: "user_active_connections", help: "Number of active connections by user.", kind: prometheusGauge}
+
+	for _, r := range rows {
+		connections.add(float64(r.Connections), "user", r.Username)
+		active.add(float64(r.Active), "user", r.Username)
+	}
+
+	return []*family{connections, active}
+}
+
+// labelEscaper escapes label values as required by the exposition format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeFamily writes the HELP and TYPE lines followed by each sample
+func writeFamily(w *bufio.Writer, f *family) {
+	name := metricPrefix + f.name
+
+	_, _ = w.WriteString("# HELP " + name + " " + f.help + "\n")
+	_, _ = w.WriteString("# TYPE " + name + " " + f.kind + "\n")
+	for _, s := range f.samples {
+		_, _ = w.WriteString(name)
+		if len(s.labels) > 0 {
+			_ = w.WriteByte('{')
+			for i := 0; i+1 < len(s.labels); i += 2 {
+				if i > 0 {
+					_ = w.WriteByte(',')
+				}
+				_, _ = w.WriteString(s.labels[i] + `="` + labelEscaper.Replace(s.labels[i+1]) + `"`)
+			}
+			_ = w.WriteByte('}')
+		}
+		_, _ = w.WriteString(" " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+	}
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sjmudd/ps-top/model/tableio"
+	"github.com/sjmudd/ps-top/model/userlatency"
+)
+
+// rowsData provides the given rows for testing WritePrometheus.
+type rowsData struct {
+	mockData
+	results any
+}
+
+func (r rowsData) RawResults() any { return r.results }
+
+func TestWritePrometheus(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WritePrometheus(&buf,
+		rowsData{results: []tableio.Row{{Name: `db."t"`, SumTimerFetch: 2500000000000, CountFetch: 7}}},
+		rowsData{results: []userlatency.Row{{Username: "app", Connections: 3, Active: 1}}},
+		rowsData{results: []string{"ignored"}},
+	)
+	if err != nil {
+		t.Fatalf("WritePrometheus() failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, expected := range []string{
+		"# HELP pstop_table_io_wait_seconds_total ",
+		"# TYPE pstop_table_io_wait_seconds_total counter\n",
+		`pstop_table_io_wait_seconds_total{table="db.\"t\"",operation="fetch"} 2.5` + "\n",
+		`pstop_table_io_operations_total{table="db.\"t\"",operation="fetch"} 7` + "\n",
+		`pstop_table_io_operations_total{table="db.\"t\"",operation="delete"} 0` + "\n",
+		"# TYPE pstop_user_connections gauge\n",
+		`pstop_user_connections{user="app"} 3` + "\n",
+		`pstop_user_active_connections{user="app"} 1` + "\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("output missing %q:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "ignored") {
+		t.Errorf("unexpected output for unknown rows:\n%s", out)
+	}
+}
+
+// TestWritePrometheusRelative checks relative data is rejected.
+func TestWritePrometheusRelative(t *testing.T) {
+	var buf bytes.Buffer
+
+	data := rowsData{mockData: mockData{haveRel: true, wantRel: true}, results: []tableio.Row{}}
+	if err := WritePrometheus(&buf, data); err == nil {
+		t.Error("expected an error writing relative data")
+	}
+}
@@ -88,6 +88,42 @@ func Simplify(path string, munger Munger, qualifiedNamer QualifiedNamer, datadir
 	return cache.put(path, uncachedSimplify(path, munger, qualifiedNamer, datadir, relaylog))
 }
 
+// Categorise converts the filename into the category of file it belongs
+// to: the data files of each schema, "<ibdata>", "<redo_log>",
+// "<undo_log>", "<doublewrite>", "<binlog>", "<relay_log>", "<temp>" for
+// temporary tables and tablespaces, or "<other>".
+// Values used here are cached for performance reasons.
+func Categorise(path string, schemaNamer Munger, datadir string, relaylog string) string {
+	if cachedResult, err := categoryCache.get(path); err == nil {
+		return cachedResult
+	}
+
+	return categoryCache.put(path, uncachedCategorise(path, schemaNamer, datadir, relaylog))
+}
+
+// uncachedCategorise converts the filename into its category.
+func uncachedCategorise(path string, schemaNamer Munger, datadir string, relaylog string) string {
+	path = reDollar.ReplaceAllLiteralString(path, "$")
+
+	if m := reTableFile.FindStringSubmatch(path); m != nil {
+		if reTempTable.MatchString(m[2]) {
+			return "<temp>"
+		}
+		return "data: " + schemaNamer(m[1])
+	}
+
+	// table files have been handled so the names are not munged
+	noop := func(name string) string { return name }
+	qualifiedName := func(schema, table string) string { return schema + "." + table }
+	switch name := uncachedSimplify(path, noop, qualifiedName, datadir, relaylog); name {
+	case "<temp_table>", "<ibtmp>":
+		return "<temp>"
+	case "<ibdata>", "<redo_log>", "<undo_log>", "<doublewrite>", "<binlog>", "<relay_log>":
+		return name
+	}
+	return "<other>"
+}
+
 // The Config interface is to pull out a value given a config setting
 type Config interface {
 	Get(setting string) string
@@ -64,3 +64,38 @@ func TestSimplify(t *testing.T) {
 		}
 	}
 }
+
+func TestCategorise(t *testing.T) {
+	const (
+		datadir  = "/path/to/datadir/"
+		relaylog = "relay-bin"
+	)
+	var tests = []struct {
+		path     string
+		expected string
+	}{
+		{`/path/to/datadir/somedb/sometable.ibd`, `data: somedb`},
+		{`/path/to/datadir/somedb/sometable#P#p0001.ibd`, `data: somedb`},
+		{`/path/to/datadir/some@0024db/sometable.ibd`, `data: some$db`},
+		{`/path/to/datadir/somedb/#sql-12345.ibd`, `<temp>`},
+		{`/path/to/datadir/#innodb_temp/temp_6.ibt`, `<temp>`},
+		{`/path/to/datadir/ibtmp1`, `<temp>`},
+		{`/path/to/datadir/ibdata1`, `<ibdata>`},
+		{`/path/to/datadir/ib_logfile0`, `<redo_log>`},
+		{`/path/to/datadir/#innodb_redo/#ib_redo4226`, `<redo_log>`},
+		{`/path/to/datadir/undo_001`, `<undo_log>`},
+		{`/path/to/datadir/#ib_16384_0.dblwr`, `<doublewrite>`},
+		{`/path/to/datadir/binlog.000042`, `<binlog>`},
+		{`/path/to/datadir/relay-bin.000007`, `<relay_log>`},
+		{`/path/to/datadir/relay-bin.index`, `<relay_log>`},
+		{`/path/to/datadir/auto.cnf`, `<other>`},
+		{`/path/to/datadir/whatever`, `<other>`},
+	}
+
+	for _, test := range tests {
+		got := uncachedCategorise(test.path, noopMunger, datadir, relaylog)
+		if got != test.expected {
+			t.Errorf("uncachedCategorise(%q) != expected %q, got: %q", test.path, test.expected, got)
+		}
+	}
+}
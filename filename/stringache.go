@@ -2,23 +2,26 @@ package filename
 
 import (
 	"errors"
+	"sync"
 )
 
 // stringCache provides a mapping from filename to table.schema etc.
-// No protection against concurrent access is provided as this
-// structure is expected to be accessed sequentially by a single
-// go-routine.
-// Some counters are collected
+// It may be used concurrently when collecting from several servers.
 type stringCache struct {
+	mu    sync.Mutex
 	cache map[string]string
 }
 
 var (
-	cache stringCache
+	cache         stringCache
+	categoryCache stringCache
 )
 
 // get will return the value in the cache if found
 func (sc *stringCache) get(key string) (result string, err error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
 	if sc.cache == nil {
 		//	logger.Println("stringCache.get() sc is nil, enabling cache")
 		sc.cache = make(map[string]string)
@@ -33,6 +36,9 @@ func (sc *stringCache) get(key string) (result string, err error) {
 
 // put writes to cache and return the value saved.
 func (sc *stringCache) put(key, value string) string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
 	sc.cache[key] = value
 
 	return value
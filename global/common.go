@@ -2,8 +2,11 @@
 package global
 
 import (
+	"errors"
 	"strconv"
 	"strings"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 const (
@@ -12,17 +15,10 @@ const (
 )
 
 // We expect to use I_S to query Global Variables. 5.7+ now wants us to use P_S,
-// so this variable will be changed if we see the show_compatibility_56 error message
-
-// globally used by Status and Variables
-var seenCompatibilityError bool
-
-// shared by Status and Variables
-// - no locking. Not sure if absolutely necessary.
-func usePerformanceSchema() {
-	seenCompatibilityError = true
-	statusTable = performanceSchemaGlobalStatus
-	variablesTable = performanceSchemaGlobalVariables
+// so each Status and Variables changes to P_S if it sees one of these errors.
+// Each server may be a different version so the table used is not shared.
+func compatibilityError(err error) bool {
+	return IsMysqlError(err, showCompatibility56ErrorNum) || IsMysqlError(err, variablesNotInISErrorNum)
 }
 
 // IsMysqlError returns true if the given error matches the expected number
@@ -30,7 +26,13 @@ func usePerformanceSchema() {
 //     so adjusting code to handle the expected format
 //
 // Error 1109 (42S02): Unknown table 'GLOBAL_VARIABLES' in information_schema
+//
+// Errors from the driver, which may be wrapped, are checked by number.
 func IsMysqlError(err error, wantedErrNum int) bool {
+	var mysqlError *mysql.MySQLError
+	if errors.As(err, &mysqlError) {
+		return int(mysqlError.Number) == wantedErrNum
+	}
 	s := err.Error()
 	if len(s) < 19 {
 		return false
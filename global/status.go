@@ -3,6 +3,9 @@ package global
 
 import (
 	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/sjmudd/ps-top/log"
 )
@@ -10,26 +13,48 @@ import (
 const (
 	informationSchemaGlobalStatus = "INFORMATION_SCHEMA.GLOBAL_STATUS"
 	performanceSchemaGlobalStatus = "performance_schema.global_status"
+	querySelectStatusIS           = "SELECT VARIABLE_NAME, VARIABLE_VALUE FROM INFORMATION_SCHEMA.GLOBAL_STATUS"
+	querySelectStatusPS           = "SELECT VARIABLE_NAME, VARIABLE_VALUE FROM performance_schema.global_status"
 )
 
-// may be modified by usePerformanceSchema()
-var statusTable = informationSchemaGlobalStatus
+// Querier is the part of a database handle such as *sql.DB used to query the status
+type Querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
 
 // Status holds a handle to the database where the status can be queried
 type Status struct {
-	db *sql.DB
+	db     Querier
+	table  string         // table the status is read from, changed by usePerformanceSchema()
+	values map[string]int // values to return when there is no database, e.g. when replaying
 }
 
 // NewStatus returns a *Status structure to the user
-func NewStatus(db *sql.DB) *Status {
+func NewStatus(db Querier) *Status {
 	if db == nil {
 		log.Fatal("NewStatus() db is nil")
 	}
 	return &Status{
-		db: db,
+		db:    db,
+		table: informationSchemaGlobalStatus,
 	}
 }
 
+// NewFixedStatus returns a *Status which returns the values provided
+// by Set() rather than querying a database.
+func NewFixedStatus() *Status {
+	return &Status{
+		values: make(map[string]int),
+	}
+}
+
+// Set sets the value to be returned for the given name by a Status
+// created with NewFixedStatus()
+func (status *Status) Set(name string, value int) {
+	status.values[name] = value
+}
+
 /*
 ** mysql> select VARIABLE_VALUE from global_status where VARIABLE_NAME = 'UPTIME';
 * +----------------+
@@ -45,9 +70,17 @@ func NewStatus(db *sql.DB) *Status {
 func (status *Status) Get(name string) int {
 	var value int
 
-	query := "SELECT VARIABLE_VALUE FROM " + statusTable + " WHERE VARIABLE_NAME = ?"
+	if status.db == nil {
+		return status.values[name]
+	}
+
+	query := "SELECT VARIABLE_VALUE FROM " + status.table + " WHERE VARIABLE_NAME = ?"
 
 	err := status.db.QueryRow(query, name).Scan(&value)
+	if err != nil && status.usePerformanceSchema(err) {
+		query = "SELECT VARIABLE_VALUE FROM " + status.table + " WHERE VARIABLE_NAME = ?"
+		err = status.db.QueryRow(query, name).Scan(&value)
+	}
 	switch {
 	case err == sql.ErrNoRows:
 		log.Println("Status.Get("+name+"): no status with this name, query:", query)
@@ -63,3 +96,57 @@ func (status *Status) Get(name string) int {
 
 	return value
 }
+
+// All returns the values of all the global status variables with one query.
+// - all returned keys are lower-cased.
+// - note: as with Get() we assume the status table has already been checked
+// - the error is returned, e.g. if the user may not read the table
+func (status *Status) All() (map[string]string, error) {
+	all := make(map[string]string)
+
+	if status.db == nil {
+		for name, value := range status.values {
+			all[strings.ToLower(name)] = strconv.Itoa(value)
+		}
+		return all, nil
+	}
+
+	// pick the pre-built query for the table in use (see Variables.selectAll)
+	query := querySelectStatusIS
+	if status.table == performanceSchemaGlobalStatus {
+		query = querySelectStatusPS
+	}
+
+	rows, err := status.db.Query(query)
+	if err != nil && status.usePerformanceSchema(err) {
+		query = querySelectStatusPS
+		rows, err = status.db.Query(query)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Status.All: query: %s failed with: %w", query, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, fmt.Errorf("Status.All: %w", err)
+		}
+		all[strings.ToLower(name)] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Status.All: %w", err)
+	}
+
+	return all, nil
+}
+
+// usePerformanceSchema changes to reading the status from
+// performance_schema if err shows it is needed, returning true if so.
+func (status *Status) usePerformanceSchema(err error) bool {
+	if status.table == performanceSchemaGlobalStatus || !compatibilityError(err) {
+		return false
+	}
+	log.Println("Status: reading", status.table, "failed, trying with P_S:", err)
+	status.table = performanceSchemaGlobalStatus
+	return true
+}
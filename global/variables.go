@@ -15,12 +15,10 @@ const (
 	querySelectVariablesPS           = "SELECT VARIABLE_NAME, VARIABLE_VALUE FROM performance_schema.global_variables"
 )
 
-// may be modified by usePerformanceSchema()
-var variablesTable = informationSchemaGlobalVariables // default
-
 // Variables holds the handle and variables collected from the database
 type Variables struct {
 	db        *sql.DB
+	table     string // table the variables are read from, changed to P_S if needed
 	variables map[string]string
 }
 
@@ -31,11 +29,33 @@ func NewVariables(db *sql.DB) *Variables {
 	}
 
 	v := &Variables{
-		db: db,
+		db:    db,
+		table: informationSchemaGlobalVariables,
 	}
 	return v.selectAll()
 }
 
+// NewFixedVariables returns a pointer to a Variables structure holding
+// the given values. No database is used.
+func NewFixedVariables(variables map[string]string) *Variables {
+	v := &Variables{
+		variables: make(map[string]string, len(variables)),
+	}
+	for key, value := range variables {
+		v.variables[strings.ToLower(key)] = value
+	}
+	return v
+}
+
+// All returns a copy of all the variables collected.
+func (v Variables) All() map[string]string {
+	all := make(map[string]string, len(v.variables))
+	for key, value := range v.variables {
+		all[key] = value
+	}
+	return all
+}
+
 // Get returns the value of the given variable if found or an empty string if not.
 func (v Variables) Get(key string) string {
 	var result string
@@ -56,11 +76,11 @@ func (v *Variables) selectAll() *Variables {
 	// Build query using known safe constants rather than concatenating
 	// table/identifier names. gosec flags concatenation into SQL strings
 	// (G202) because it can lead to SQL injection if the concatenated
-	// value is untrusted. Here `variablesTable` is an internal variable
-	// set only by `usePerformanceSchema()` to one of the two known
-	// constants, so pick the corresponding pre-built query string.
+	// value is untrusted. Here `v.table` is an internal field set only
+	// to one of the two known constants, so pick the corresponding
+	// pre-built query string.
 	var query string
-	if variablesTable == performanceSchemaGlobalVariables {
+	if v.table == performanceSchemaGlobalVariables {
 		query = querySelectVariablesPS
 	} else {
 		query = querySelectVariablesIS
@@ -69,15 +89,10 @@ func (v *Variables) selectAll() *Variables {
 
 	rows, err := v.db.Query(query)
 	if err != nil {
-		if !seenCompatibilityError && (IsMysqlError(err, showCompatibility56ErrorNum) || IsMysqlError(err, variablesNotInISErrorNum)) {
+		if v.table == informationSchemaGlobalVariables && compatibilityError(err) {
 			log.Println("Variables.selectAll: query: '", query, "' failed, trying with P_S")
-			usePerformanceSchema()
-			// Re-evaluate which query to use after switching to performance_schema
-			if variablesTable == performanceSchemaGlobalVariables {
-				query = querySelectVariablesPS
-			} else {
-				query = querySelectVariablesIS
-			}
+			v.table = performanceSchemaGlobalVariables
+			query = querySelectVariablesPS
 			log.Println("query:", query)
 
 			rows, err = v.db.Query(query)
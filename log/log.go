@@ -4,9 +4,12 @@
 package log
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"strings"
+	"sync/atomic"
 )
 
 func setLoggingDestination(flags int, destination io.Writer) {
@@ -48,10 +51,46 @@ func SetupLogging(enable bool, logfile string) {
 // So write logging as configured and then write to stderr where the
 // user will see it.
 
+// FatalError is the error of a Fatal*() call made inside Recover()
+type FatalError string
+
+// Error returns the message passed to Fatal*()
+func (e FatalError) Error() string {
+	return string(e)
+}
+
+// recovering counts the calls of Recover() in progress. While it is
+// non-zero Fatal*() panics with a FatalError instead of exiting.
+var recovering atomic.Int32
+
+// Recover calls fn and returns the error of any Fatal*() call it makes
+// rather than exiting, e.g. so that one of several servers failing does
+// not stop ps-top. Fatal*() calls made by other goroutines while fn runs
+// are also recovered so should only be made by functions run by Recover().
+func Recover(fn func()) (err error) {
+	recovering.Add(1)
+	defer recovering.Add(-1)
+	defer func() {
+		if r := recover(); r != nil {
+			fatal, ok := r.(FatalError)
+			if !ok {
+				panic(r)
+			}
+			err = fatal
+		}
+	}()
+
+	fn()
+	return nil
+}
+
 // Fatal logs to file (if enabled) and also to stderr
 func Fatal(v ...any) {
 	// Always attempt to write the message using the configured logger (may be discarded).
 	log.Print(v...)
+	if recovering.Load() > 0 {
+		panic(FatalError(fmt.Sprint(v...)))
+	}
 
 	// If logging was disabled, ensure the user sees the fatal message on stderr
 	// and then exit. If logging is enabled the MultiWriter will already include stderr.
@@ -64,6 +103,9 @@ func Fatal(v ...any) {
 // Fatalf logs to file (if enabled) and also to stderr
 func Fatalf(format string, v ...any) {
 	log.Printf(format, v...)
+	if recovering.Load() > 0 {
+		panic(FatalError(fmt.Sprintf(format, v...)))
+	}
 	if !loggingEnabled {
 		setOutputOnly(os.Stderr)
 	}
@@ -73,6 +115,9 @@ func Fatalf(format string, v ...any) {
 // Fatalln logs to file (if enabled) and also to stderr
 func Fatalln(v ...any) {
 	log.Println(v...)
+	if recovering.Load() > 0 {
+		panic(FatalError(strings.TrimSuffix(fmt.Sprintln(v...), "\n")))
+	}
 	if !loggingEnabled {
 		setOutputOnly(os.Stderr)
 	}
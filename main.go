@@ -12,6 +12,8 @@ import (
 
 	"github.com/sjmudd/ps-top/app"
 	"github.com/sjmudd/ps-top/connector"
+	"github.com/sjmudd/ps-top/dashboard"
+	"github.com/sjmudd/ps-top/export"
 	"github.com/sjmudd/ps-top/log"
 	"github.com/sjmudd/ps-top/model/filter"
 	"github.com/sjmudd/ps-top/utils"
@@ -22,10 +24,18 @@ var (
 	cpuprofile         = flag.String("cpuprofile", "", "write cpu profile to file")
 	flagAnonymise      = flag.Bool("anonymise", false, "Anonymise hostname, user, db and table names (default: false)")
 	flagAskpass        = flag.Bool("askpass", false, "Ask for password interactively")
+	flagBatch          = flag.Bool("batch", false, "Write output as plain text to stdout rather than using the screen")
+	flagCount          = flag.Int("count", 0, "Number of collections to show before exiting, implies --batch (default: 0, no limit)")
 	flagDatabaseFilter = flag.String("database-filter", "", "Optional comma-separated filter of database names")
 	flagDebug          = flag.Bool("debug", false, "Enabling debug logging to ps-top.log")
+	flagFormat         = flag.String("format", "text", "Format of batch mode output: text, json or csv, json and csv imply --batch (default: text)")
 	flagHelp           = flag.Bool("help", false, "Provide some help for "+utils.ProgName)
 	flagInterval       = flag.Int("interval", 1, "Set the initial poll interval (default 1 second)")
+	flagLayout         = flag.String("layout", "stacked", "Layout used to show several servers: stacked or merged (default: stacked)")
+	flagListen         = flag.String("listen", "", "Serve Prometheus metrics on the given address, e.g. :9104, instead of using the screen")
+	flagRecord         = flag.String("record", "", "Record the collected data to the given file so it can be replayed later")
+	flagReplay         = flag.String("replay", "", "Replay the data recorded in the given file instead of connecting to MySQL")
+	flagServers        = flag.String("servers", "", "Optional comma-separated list of servers (host[:port], /path/to/socket or @group) to show together")
 	flagVersion        = flag.Bool("version", false, "Show the version of "+utils.ProgName)
 	flagView           = flag.String("view", "", "Provide view to show when starting "+utils.ProgName+" (default: table_io_latency)")
 
@@ -44,19 +54,27 @@ func usage() {
 		"Options:",
 		"--anonymise=<true|false>                 Anonymise hostname, user, db and table names",
 		"--askpass                                Request password to be provided interactively",
+		"--batch                                  Write output as plain text to stdout (no terminal required)",
+		"--count=<n>                              Number of collections to show before exiting, implies --batch",
 		"--database-filter=db1[,db2,db3,...]      Optional database names to filter on, default ''",
 		"--defaults-file=/path/to/defaults.file   Connect to MySQL using given defaults-file, default ~/.my.cnf",
+		"--format=<text|json|csv>                 Format of batch mode output, json writes one record per line (NDJSON), csv writes raw column values. Both imply --batch",
 		"--help                                   Show this help message",
 		"--host=<hostname>                        MySQL host to connect to",
 		"--interval=<seconds>                     Set the default poll interval (in seconds)",
+		"--layout=<stacked|merged>                Show the rows of each server of --servers one after the other, or sorted together with a server column",
+		"--listen=<[host]:port>                   Serve Prometheus metrics on http://<host:port>/metrics instead of using the screen",
 		"--password=<password>                    Password to use when connecting",
 		"--port=<port>                            MySQL port to connect to",
+		"--record=<file>                          Record the collected data of all views to the given file",
+		"--replay=<file>                          Replay a recording instead of connecting to MySQL, use n/p to step through it",
+		"--servers=server1[,server2,...]          Show several servers together, each given as host[:port], /path/to/socket or @group of the defaults-file",
 		"--socket=<path>                          MySQL path of the socket to connect to",
 		"--user=<user>                            User to connect with",
 		"--use-environment                        Connect to MySQL using a go dsn collected from MYSQL_DSN e.g. MYSQL_DSN='test_user:test_pass@tcp(127.0.0.1:3306)/performance_schema'",
 		"--version                                Show the version",
 		"--view=<view>                            Determine the view you want to see when " + utils.ProgName + " starts (default: table_io_latency)",
-		"                                         Possible values: table_io_latency table_io_ops file_io_latency table_lock_latency user_latency mutex_latency stages_latency",
+		"                                         Possible values: table_io_latency table_io_ops file_io_latency table_lock_latency user_latency mutex_latency stages_latency memory_usage statement_digest running_statements lock_waits metadata_locks replication index_usage unused_indexes account_activity host_activity global_status transactions statement_histogram stage_progress file_io_category file_io_event socket_io_type socket_io_address memory_by_owner",
 	}
 
 	for _, line := range lines {
@@ -121,12 +139,48 @@ func main() {
 		return
 	}
 
+	if *flagCount < 0 {
+		fmt.Printf("Invalid --count value: %d\n", *flagCount)
+		return
+	}
+
+	format, err := export.ParseFormat(*flagFormat)
+	if err != nil {
+		fmt.Printf("Invalid --format value: %v\n", err)
+		return
+	}
+
+	if *flagReplay != "" && (*flagRecord != "" || *flagListen != "") {
+		fmt.Println("--replay can not be used with --record or --listen")
+		return
+	}
+
+	layout, err := dashboard.ParseLayout(*flagLayout)
+	if err != nil {
+		fmt.Printf("Invalid --layout value: %v\n", err)
+		return
+	}
+
+	servers := connector.ParseTargets(*flagServers)
+	if len(servers) > 0 && (*flagReplay != "" || *flagRecord != "" || *flagListen != "" || format != export.FormatText) {
+		fmt.Println("--servers can not be used with --replay, --record, --listen or --format=json|csv")
+		return
+	}
+
 	app, err := app.NewApp(
 		connectorConfig,
 		app.Settings{
 			Anonymise: *flagAnonymise,
+			Batch:     *flagBatch || *flagCount > 0 || format != export.FormatText,
+			Count:     *flagCount,
+			Format:    format,
 			Filter:    filter.NewDatabaseFilter(*flagDatabaseFilter),
 			Interval:  *flagInterval,
+			Layout:    layout,
+			Listen:    *flagListen,
+			Record:    *flagRecord,
+			Replay:    *flagReplay,
+			Servers:   servers,
 			ViewName:  *flagView,
 		},
 	)
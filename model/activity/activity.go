@@ -0,0 +1,64 @@
+package activity
+
+import (
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+)
+
+// Activity holds the statement activity of each account or host
+type Activity struct {
+	*model.BaseCollector[Row, Rows]
+	collection collection
+}
+
+// NewAccountActivity creates a new Activity instance collecting each account (user@host).
+func NewAccountActivity(cfg model.Config, db model.QueryExecutor) *Activity {
+	return newActivity(cfg, db, byAccount)
+}
+
+// NewHostActivity creates a new Activity instance collecting each host.
+func NewHostActivity(cfg model.Config, db model.QueryExecutor) *Activity {
+	return newActivity(cfg, db, byHost)
+}
+
+func newActivity(cfg model.Config, db model.QueryExecutor, c collection) *Activity {
+	process := func(last, first Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		if cfg.WantRelativeStats() {
+			common.SubtractByName(&results, first,
+				func(r Row) string { return r.Name },
+				func(r *Row, o Row) { r.subtract(o) },
+			)
+		}
+		tot := totals(results)
+		return results, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &Activity{BaseCollector: bc, collection: c}
+}
+
+// Collect collects data from the db, updating initial values
+// if needed, and then subtracting initial values if we want relative
+// values, after which it stores totals.
+func (a *Activity) Collect() {
+	bc := a.BaseCollector
+	fetch := func() (Rows, error) {
+		return collect(bc.DB(), a.collection)
+	}
+	wantRefresh := func() bool {
+		// the tables have been truncated if the totals go down
+		return (len(bc.First) == 0 && len(bc.Last) > 0) || totals(bc.First).SumTimerWait > totals(bc.Last).SumTimerWait
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats is true for this object
+func (a Activity) HaveRelativeStats() bool {
+	return true
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (a Activity) WantRelativeStats() bool {
+	return a.Config().WantRelativeStats()
+}
@@ -0,0 +1,71 @@
+// Package activity contains the routines for managing the statement
+// activity of each account or host, taken from
+// performance_schema.events_statements_summary_by_account_by_event_name
+// and accounts, or events_statements_summary_by_host_by_event_name and hosts.
+package activity
+
+/*
+// MySQL 8.4 (columns used)
+CREATE TABLE `events_statements_summary_by_account_by_event_name` (
+  `USER` char(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL,
+  `HOST` char(255) CHARACTER SET ascii COLLATE ascii_general_ci DEFAULT NULL,
+  `EVENT_NAME` varchar(128) NOT NULL,
+  `COUNT_STAR` bigint unsigned NOT NULL,
+  `SUM_TIMER_WAIT` bigint unsigned NOT NULL,
+  `SUM_ROWS_SENT` bigint unsigned NOT NULL,
+  `SUM_ROWS_EXAMINED` bigint unsigned NOT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+CREATE TABLE `accounts` (
+  `USER` char(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL,
+  `HOST` char(255) CHARACTER SET ascii COLLATE ascii_general_ci DEFAULT NULL,
+  `CURRENT_CONNECTIONS` bigint NOT NULL,
+  `TOTAL_CONNECTIONS` bigint NOT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+events_statements_summary_by_host_by_event_name and hosts are the same
+without the USER column. Rows with a NULL USER or HOST are for
+background threads and are ignored.
+*/
+
+// Row contains the statements run by an account or host
+type Row struct {
+	Name string // user@host or host
+
+	SumTimerWait       uint64
+	CountStar          uint64
+	Selects            uint64
+	Inserts            uint64 // includes REPLACE
+	Updates            uint64
+	Deletes            uint64
+	SumRowsExamined    uint64
+	SumRowsSent        uint64
+	CurrentConnections uint64 // this is not a counter
+	TotalConnections   uint64
+}
+
+// Other returns the number of statements which are not a SELECT, INSERT, UPDATE or DELETE
+func (row Row) Other() uint64 {
+	return row.CountStar - row.Selects - row.Inserts - row.Updates - row.Deletes
+}
+
+// subtract the countable values in one row from another.
+// CurrentConnections is kept as it is the current value.
+func (row *Row) subtract(other Row) {
+	row.SumTimerWait -= other.SumTimerWait
+	row.CountStar -= other.CountStar
+	row.Selects -= other.Selects
+	row.Inserts -= other.Inserts
+	row.Updates -= other.Updates
+	row.Deletes -= other.Deletes
+	row.SumRowsExamined -= other.SumRowsExamined
+	row.SumRowsSent -= other.SumRowsSent
+	row.TotalConnections -= other.TotalConnections
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && (row.CountStar > 0 || row.CurrentConnections > 0)
+}
@@ -0,0 +1,23 @@
+package activity
+
+import (
+	"testing"
+)
+
+func TestSubtract(t *testing.T) {
+	row := Row{Name: "app@web1", SumTimerWait: 100, CountStar: 10, Selects: 6, Inserts: 2, Updates: 1, Deletes: 1, SumRowsExamined: 50, SumRowsSent: 20, CurrentConnections: 3, TotalConnections: 8}
+	initial := Row{Name: "app@web1", SumTimerWait: 40, CountStar: 4, Selects: 3, Inserts: 1, SumRowsExamined: 10, SumRowsSent: 5, CurrentConnections: 2, TotalConnections: 5}
+	expected := Row{Name: "app@web1", SumTimerWait: 60, CountStar: 6, Selects: 3, Inserts: 1, Updates: 1, Deletes: 1, SumRowsExamined: 40, SumRowsSent: 15, CurrentConnections: 3, TotalConnections: 3}
+
+	row.subtract(initial)
+	if row != expected {
+		t.Errorf("subtract() failed: expected: %+v, got: %+v", expected, row)
+	}
+}
+
+func TestOther(t *testing.T) {
+	row := Row{CountStar: 10, Selects: 4, Inserts: 2, Updates: 1, Deletes: 1}
+	if got := row.Other(); got != 2 {
+		t.Errorf("Other() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,109 @@
+package activity
+
+import (
+	"fmt"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// collection selects what is collected
+type collection int
+
+const (
+	byAccount collection = iota // events_statements_summary_by_account_by_event_name
+	byHost                      // events_statements_summary_by_host_by_event_name
+)
+
+const statementColumns = `
+	SUM(s.SUM_TIMER_WAIT),
+	SUM(s.COUNT_STAR),
+	SUM(IF(s.EVENT_NAME = 'statement/sql/select', s.COUNT_STAR, 0)),
+	SUM(IF(s.EVENT_NAME IN ('statement/sql/insert', 'statement/sql/insert_select', 'statement/sql/replace', 'statement/sql/replace_select'), s.COUNT_STAR, 0)),
+	SUM(IF(s.EVENT_NAME IN ('statement/sql/update', 'statement/sql/update_multi'), s.COUNT_STAR, 0)),
+	SUM(IF(s.EVENT_NAME IN ('statement/sql/delete', 'statement/sql/delete_multi'), s.COUNT_STAR, 0)),
+	SUM(s.SUM_ROWS_EXAMINED),
+	SUM(s.SUM_ROWS_SENT),
+	COALESCE(MAX(c.CURRENT_CONNECTIONS), 0),
+	COALESCE(MAX(c.TOTAL_CONNECTIONS), 0)`
+
+// collectSQL holds the query used for each collection
+var collectSQL = map[collection]string{
+	byAccount: `
+SELECT	s.USER,
+	s.HOST,` + statementColumns + `
+FROM	events_statements_summary_by_account_by_event_name s
+LEFT JOIN accounts c ON c.USER = s.USER AND c.HOST = s.HOST
+WHERE	s.USER IS NOT NULL AND s.HOST IS NOT NULL
+GROUP BY s.USER, s.HOST`,
+	byHost: `
+SELECT	'',
+	s.HOST,` + statementColumns + `
+FROM	events_statements_summary_by_host_by_event_name s
+LEFT JOIN hosts c ON c.HOST = s.HOST
+WHERE	s.HOST IS NOT NULL
+GROUP BY s.HOST`,
+}
+
+// Rows contains a set of rows
+type Rows []Row
+
+func totals(rows Rows) Row {
+	total := Row{Name: "Totals"}
+
+	for _, row := range rows {
+		total.SumTimerWait += row.SumTimerWait
+		total.CountStar += row.CountStar
+		total.Selects += row.Selects
+		total.Inserts += row.Inserts
+		total.Updates += row.Updates
+		total.Deletes += row.Deletes
+		total.SumRowsExamined += row.SumRowsExamined
+		total.SumRowsSent += row.SumRowsSent
+		total.CurrentConnections += row.CurrentConnections
+		total.TotalConnections += row.TotalConnections
+	}
+
+	return total
+}
+
+func collect(db model.QueryExecutor, c collection) (Rows, error) {
+	log.Printf("collect(?,%v)\n", c)
+
+	rows, err := db.Query(collectSQL[c])
+	if err != nil {
+		// the tables may be missing or not readable by the user
+		if model.IsExpectedError(err) {
+			log.Printf("activity.collect: ignoring expected error: %v", err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("activity.collect: %w", err)
+	}
+
+	return common.Collect(rows, func() (Row, error) {
+		var user, host string
+		var r Row
+		if err := rows.Scan(
+			&user,
+			&host,
+			&r.SumTimerWait,
+			&r.CountStar,
+			&r.Selects,
+			&r.Inserts,
+			&r.Updates,
+			&r.Deletes,
+			&r.SumRowsExamined,
+			&r.SumRowsSent,
+			&r.CurrentConnections,
+			&r.TotalConnections); err != nil {
+			return r, err
+		}
+		r.Name = host
+		if c == byAccount {
+			r.Name = utils.Anonymise("user", user) + "@" + host
+		}
+		return r, nil
+	}), nil
+}
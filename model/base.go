@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"github.com/sjmudd/ps-top/log"
 )
 
 // BaseCollector encapsulates the common collection state and logic for all models.
@@ -18,6 +20,10 @@ type BaseCollector[T any, R ~[]T] struct {
 	Results        R // processed results (after subtraction, etc.)
 	Totals         T // totals row computed from results
 	process        ProcessFunc[T, R]
+
+	replaying  bool      // use replayed data rather than fetching it from the database
+	replayLast R         // replayed raw data
+	replayTime time.Time // time the replayed data was originally collected
 }
 
 // NewBaseCollector creates a new BaseCollector with the given config, database, and process function.
@@ -42,23 +48,28 @@ type WantRefreshFunc = func() bool
 type FetchFunc[R any] func() (R, error)
 
 // Collect orchestrates a full collection cycle:
-// 1. Fetch raw data via fetchFunc
+// 1. Fetch raw data via fetchFunc (or use the replayed data if replaying)
 // 2. Optionally refresh baseline if wantRefresh returns true
 // 3. Process data via the stored process function to produce results and totals
 func (bc *BaseCollector[T, R]) Collect(
 	fetch FetchFunc[R],
 	wantRefresh WantRefreshFunc,
 ) {
-	// Fetch the latest data
-	last, err := fetch()
-	if err != nil {
-		// TODO: log error? For now, skip this collection cycle.
-		return
+	last, collected := bc.replayLast, bc.replayTime
+	if !bc.replaying {
+		// Fetch the latest data
+		fetched, err := fetch()
+		if err != nil {
+			// keep the previous values until the next collection
+			log.Printf("BaseCollector.Collect: skipping collection: %v", err)
+			return
+		}
+		last, collected = fetched, time.Now()
 	}
 
 	// Update last snapshot and timestamp
 	bc.Last = last
-	bc.LastCollected = time.Now()
+	bc.LastCollected = collected
 	if bc.FirstCollected.IsZero() {
 		bc.FirstCollected = bc.LastCollected
 	}
@@ -83,6 +94,40 @@ func (bc *BaseCollector[T, R]) ResetStatistics() {
 	bc.Results, bc.Totals = bc.process(bc.Last, bc.First)
 }
 
+// Reprocess recalculates the results and totals from the collected data,
+// e.g. after changing how the model processes them.
+func (bc *BaseCollector[T, R]) Reprocess() {
+	bc.Results, bc.Totals = bc.process(bc.Last, bc.First)
+}
+
+// Snapshot returns the most recent raw data collected and when it was collected.
+func (bc *BaseCollector[T, R]) Snapshot() ([]T, time.Time) {
+	last := []T(bc.Last)
+	if last == nil {
+		last = []T{}
+	}
+	return last, bc.LastCollected
+}
+
+// Baseline returns the raw data relative values are calculated from and
+// when it was collected.
+func (bc *BaseCollector[T, R]) Baseline() ([]T, time.Time) {
+	return []T(bc.First), bc.FirstCollected
+}
+
+// Replay provides previously collected raw data which is used by this and
+// subsequent calls to Collect() instead of fetching data from the database.
+func (bc *BaseCollector[T, R]) Replay(last []T, collected time.Time) {
+	bc.replaying = true
+	bc.replayLast = R(last)
+	bc.replayTime = collected
+}
+
+// Replaying returns true if replayed data is used instead of the database
+func (bc *BaseCollector[T, R]) Replaying() bool {
+	return bc.replaying
+}
+
 // Config returns the collector's configuration
 func (bc *BaseCollector[T, R]) Config() Config {
 	return bc.config
@@ -105,6 +150,9 @@ type Model[T any] interface {
 	GetLastCollected() time.Time
 	GetResults() []T
 	GetTotals() T
+	Snapshot() ([]T, time.Time)
+	Baseline() ([]T, time.Time)
+	Replay(last []T, collected time.Time)
 }
 
 // GetResults returns the results slice as a []T.
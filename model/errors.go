@@ -0,0 +1,31 @@
+package model
+
+import "github.com/sjmudd/ps-top/global"
+
+// MySQL errors expected if the server is too old to have the tables or
+// columns used or if the user may not read them.
+const (
+	ErrUnknownColumn        = 1054 // Error 1054: Unknown column
+	ErrTableAccessDenied    = 1142 // Error 1142: SELECT command denied to user
+	ErrNoSuchTable          = 1146 // Error 1146: Table doesn't exist
+	ErrSpecificAccessDenied = 1227 // Error 1227: Access denied; you need (at least one of) the PROCESS privilege(s)
+)
+
+// IsMissingError returns true if the error is because the server is too
+// old to have the tables or columns used
+func IsMissingError(err error) bool {
+	return global.IsMysqlError(err, ErrNoSuchTable) || global.IsMysqlError(err, ErrUnknownColumn)
+}
+
+// IsAccessDeniedError returns true if the error is because the user may
+// not read the tables used
+func IsAccessDeniedError(err error) bool {
+	return global.IsMysqlError(err, ErrTableAccessDenied) || global.IsMysqlError(err, ErrSpecificAccessDenied)
+}
+
+// IsExpectedError returns true if the error means the data can not be
+// collected from this server, in which case a model collects no rows
+// rather than stopping ps-top.
+func IsExpectedError(err error) bool {
+	return IsMissingError(err) || IsAccessDeniedError(err)
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestExpectedErrors(t *testing.T) {
+	var tests = []struct {
+		err          error
+		missing      bool
+		accessDenied bool
+	}{
+		{&mysql.MySQLError{Number: 1146, Message: "Table 'performance_schema.replication_applier_status_by_worker' doesn't exist"}, true, false},
+		{fmt.Errorf("query: %w", &mysql.MySQLError{Number: 1054, Message: "Unknown column 'LAST_APPLIED_TRANSACTION'"}), true, false},
+		{&mysql.MySQLError{Number: 1227, Message: "Access denied; you need (at least one of) the PROCESS privilege(s) for this operation"}, false, true},
+		{fmt.Errorf("query: %w", &mysql.MySQLError{Number: 1142, Message: "SELECT command denied"}), false, true},
+		{errors.New("Error 1146 (42S02): Table 'performance_schema.metadata_locks' doesn't exist"), true, false},
+		{&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, false, false},
+		{errors.New("invalid connection"), false, false},
+	}
+
+	for _, test := range tests {
+		if got := IsMissingError(test.err); got != test.missing {
+			t.Errorf("IsMissingError(%v) failed: expected: %v, got: %v", test.err, test.missing, got)
+		}
+		if got := IsAccessDeniedError(test.err); got != test.accessDenied {
+			t.Errorf("IsAccessDeniedError(%v) failed: expected: %v, got: %v", test.err, test.accessDenied, got)
+		}
+		if got := IsExpectedError(test.err); got != (test.missing || test.accessDenied) {
+			t.Errorf("IsExpectedError(%v) failed: expected: %v, got: %v", test.err, test.missing || test.accessDenied, got)
+		}
+	}
+}
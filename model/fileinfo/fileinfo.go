@@ -1,4 +1,6 @@
-// Package fileinfo holds the routines which manage the file_summary_by_instance table.
+// Package fileinfo holds the routines which manage the file_summary_by_instance
+// and file_summary_by_event_name tables, and the socket_summary_by_instance and
+// socket_summary_by_event_name tables which have the same columns.
 package fileinfo
 
 import (
@@ -8,12 +10,43 @@ import (
 // FileIoLatency represents the contents of the data collected from file_summary_by_instance
 type FileIoLatency struct {
 	*model.BaseCollector[Row, Rows]
+	collection collection
 }
 
 // NewFileSummaryByInstance creates a new structure and include various variable values:
 // - datadir, relay_log
 // There's no checking that these are actually provided!
 func NewFileSummaryByInstance(cfg model.Config, db model.QueryExecutor) *FileIoLatency {
+	return newFileIoLatency(cfg, db, byFile)
+}
+
+// NewFileSummaryByCategory creates a new FileIoLatency instance which rolls
+// up file_summary_by_instance by the category of each file: the data files
+// of each schema, the redo and undo logs, binlogs, relay logs etc.
+func NewFileSummaryByCategory(cfg model.Config, db model.QueryExecutor) *FileIoLatency {
+	return newFileIoLatency(cfg, db, byCategory)
+}
+
+// NewFileSummaryByEventName creates a new FileIoLatency instance collecting
+// from file_summary_by_event_name.
+func NewFileSummaryByEventName(cfg model.Config, db model.QueryExecutor) *FileIoLatency {
+	return newFileIoLatency(cfg, db, byEventName)
+}
+
+// NewSocketSummaryByType creates a new FileIoLatency instance collecting the
+// socket I/O of each type of socket from socket_summary_by_event_name.
+func NewSocketSummaryByType(cfg model.Config, db model.QueryExecutor) *FileIoLatency {
+	return newFileIoLatency(cfg, db, bySocketType)
+}
+
+// NewSocketSummaryByAddress creates a new FileIoLatency instance collecting
+// the socket I/O of the client connections from each remote address from
+// socket_summary_by_instance.
+func NewSocketSummaryByAddress(cfg model.Config, db model.QueryExecutor) *FileIoLatency {
+	return newFileIoLatency(cfg, db, bySocketAddress)
+}
+
+func newFileIoLatency(cfg model.Config, db model.QueryExecutor, c collection) *FileIoLatency {
 	process := func(last, first Rows) (Rows, Row) {
 		results := make(Rows, len(last))
 		copy(results, last)
@@ -24,24 +57,38 @@ func NewFileSummaryByInstance(cfg model.Config, db model.QueryExecutor) *FileIoL
 		return results, tot
 	}
 	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
-	return &FileIoLatency{BaseCollector: bc}
+	return &FileIoLatency{BaseCollector: bc, collection: c}
 }
 
 // Collect data from the db, then merge it in.
 func (fiol *FileIoLatency) Collect() {
 	bc := fiol.BaseCollector
 	fetch := func() (Rows, error) {
-		raw := collect(bc.DB())
+		raw := collect(bc.DB(), fiol.collection)
+		datadir := bc.Config().Variables().Get("datadir")
+		relaylog := bc.Config().Variables().Get("relaylog")
+
 		// Apply transformation using config variables
-		transformed := FileInfo2MySQLNames(
-			bc.Config().Variables().Get("datadir"),
-			bc.Config().Variables().Get("relaylog"),
-			raw,
-		)
-		return transformed, nil
+		switch fiol.collection {
+		case byCategory:
+			return FileInfo2Categories(datadir, relaylog, raw), nil
+		case byEventName:
+			return EventNames(raw), nil
+		case bySocketType:
+			return SocketTypes(raw), nil
+		case bySocketAddress:
+			return SocketAddresses(raw), nil
+		default:
+			return FileInfo2MySQLNames(datadir, relaylog, raw), nil
+		}
 	}
 	wantRefresh := func() bool {
-		return (len(bc.First) == 0 && len(bc.Last) > 0) || totals(bc.First).SumTimerWait > totals(bc.Last).SumTimerWait
+		if len(bc.First) == 0 && len(bc.Last) > 0 {
+			return true
+		}
+		// the values of a socket disappear when its connection closes
+		// so the totals going down does not mean they have been reset
+		return fiol.collection != bySocketAddress && totals(bc.First).SumTimerWait > totals(bc.Last).SumTimerWait
 	}
 	bc.Collect(fetch, wantRefresh)
 }
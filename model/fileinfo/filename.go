@@ -2,6 +2,7 @@ package fileinfo
 
 import (
 	"log"
+	"strings"
 	"time"
 
 	"github.com/sjmudd/ps-top/filename"
@@ -13,12 +14,36 @@ import (
 // filenames to MySQL Object Names, merging similar names together,
 // returning the resultant rows.
 func FileInfo2MySQLNames(datadir string, relaylog string, rows []Row) []Row {
+	return merge(rows, func(name string) string {
+		return filename.Simplify(name, rc.Munge, utils.QualifiedTableName, datadir, relaylog)
+	})
+}
+
+// FileInfo2Categories converts the raw imported rows by converting
+// filenames to the category of file, such as the redo log or the data
+// files of a schema, merging the rows of each category together.
+func FileInfo2Categories(datadir string, relaylog string, rows []Row) []Row {
+	return merge(rows, func(name string) string {
+		return filename.Categorise(name, func(schema string) string { return utils.Anonymise("schema", schema) }, datadir, relaylog)
+	})
+}
+
+// EventNames simplifies the event names of the rows by removing the common
+// prefix, wait/io/file/.
+func EventNames(rows []Row) []Row {
+	return merge(rows, func(name string) string {
+		return strings.TrimPrefix(name, "wait/io/file/")
+	})
+}
+
+// merge renames the rows, merging those with the same new name together
+func merge(rows []Row, rename func(string) string) []Row {
 	start := time.Now()
 	rowsByName := make(map[string]Row)
 
 	for _, row := range rows {
 		var newRow Row
-		newName := filename.Simplify(row.Name, rc.Munge, utils.QualifiedTableName, datadir, relaylog)
+		newName := rename(row.Name)
 
 		// check if we have an entry in the map
 		if _, found := rowsByName[newName]; found {
@@ -36,7 +61,7 @@ func FileInfo2MySQLNames(datadir string, relaylog string, rows []Row) []Row {
 		newRows = append(newRows, row)
 	}
 
-	log.Printf("FileInfo2MySQLNames(): took: %v to convert %v raw rows to merged, MySQLified %v rows",
+	log.Printf("merge(): took: %v to convert %v raw rows to %v merged rows",
 		time.Since(start),
 		len(rows),
 		len(rowsByName),
@@ -9,6 +9,39 @@ import (
 	"github.com/sjmudd/ps-top/model"
 )
 
+// collection selects what is collected
+type collection int
+
+const (
+	byFile          collection = iota // file_summary_by_instance by simplified file name
+	byCategory                        // file_summary_by_instance by category of file
+	byEventName                       // file_summary_by_event_name
+	bySocketType                      // socket_summary_by_event_name
+	bySocketAddress                   // socket_summary_by_instance by remote address
+)
+
+const fileColumns = `SUM_TIMER_WAIT,
+	SUM_TIMER_READ,
+	SUM_TIMER_WRITE,
+	SUM_NUMBER_OF_BYTES_READ,
+	SUM_NUMBER_OF_BYTES_WRITE,
+	SUM_TIMER_MISC,
+	COUNT_STAR,
+	COUNT_READ,
+	COUNT_WRITE,
+	COUNT_MISC`
+
+// collectSQL holds the query used for each collection
+var collectSQL = map[collection]string{
+	byFile:       "SELECT FILE_NAME, " + fileColumns + " FROM file_summary_by_instance WHERE SUM_TIMER_WAIT > 0",
+	byCategory:   "SELECT FILE_NAME, " + fileColumns + " FROM file_summary_by_instance WHERE SUM_TIMER_WAIT > 0",
+	byEventName:  "SELECT EVENT_NAME, " + fileColumns + " FROM file_summary_by_event_name WHERE SUM_TIMER_WAIT > 0",
+	bySocketType: "SELECT EVENT_NAME, " + fileColumns + " FROM socket_summary_by_event_name WHERE SUM_TIMER_WAIT > 0",
+	// client connections are named by their remote IP, which is empty for
+	// unix socket connections, and the server listeners by their event name
+	bySocketAddress: "SELECT IF(EVENT_NAME = '" + clientConnection + "', IP, EVENT_NAME), " + fileColumns + " FROM socket_summary_by_instance JOIN socket_instances USING (EVENT_NAME, OBJECT_INSTANCE_BEGIN) WHERE SUM_TIMER_WAIT > 0",
+}
+
 // Config provides an interface for getting a configuration value from a key/value store
 type Config interface {
 	Get(setting string) string
@@ -46,29 +79,18 @@ func (rows Rows) Valid() bool {
 }
 
 // Select the raw data from the database into Rows
-func collect(db model.QueryExecutor) Rows {
-	log.Println("collect() starts")
+func collect(db model.QueryExecutor, c collection) Rows {
+	log.Printf("collect(?,%v) starts", c)
 	var t Rows
 	start := time.Now()
 
-	sql := `
-SELECT	FILE_NAME,
-	SUM_TIMER_WAIT,
-	SUM_TIMER_READ,
-	SUM_TIMER_WRITE,
-	SUM_NUMBER_OF_BYTES_READ,
-	SUM_NUMBER_OF_BYTES_WRITE,
-	SUM_TIMER_MISC,
-	COUNT_STAR,
-	COUNT_READ,
-	COUNT_WRITE,
-	COUNT_MISC
-FROM	file_summary_by_instance
-WHERE	SUM_TIMER_WAIT > 0
-`
-
-	rows, err := db.Query(sql)
+	rows, err := db.Query(collectSQL[c])
 	if err != nil {
+		// the socket tables may be missing or not readable by the user
+		if model.IsExpectedError(err) {
+			log.Printf("fileinfo.collect: ignoring expected error: %v", err)
+			return nil
+		}
 		log.Fatal(err)
 	}
 
@@ -76,7 +98,7 @@ WHERE	SUM_TIMER_WAIT > 0
 		var r Row
 
 		if err := rows.Scan(
-			&r.Name, // raw filename
+			&r.Name, // raw filename, event name or address
 			&r.SumTimerWait,
 			&r.SumTimerRead,
 			&r.SumTimerWrite,
@@ -0,0 +1,54 @@
+package fileinfo
+
+import (
+	"strings"
+
+	"github.com/sjmudd/ps-top/utils"
+)
+
+const (
+	socketPrefix     = "wait/io/socket/"
+	clientConnection = socketPrefix + "sql/client_connection"
+	unixSocket       = "<unix socket>"
+)
+
+// socketTypes holds the names shown for the socket instruments of the server
+var socketTypes = map[string]string{
+	clientConnection:                         "client connection",
+	socketPrefix + "sql/server_tcpip_socket": "server listener (tcpip)",
+	socketPrefix + "sql/server_unix_socket":  "server listener (unix)",
+}
+
+// socketType returns the name of the type of socket of the given event
+// name. Unknown instruments, such as those of plugins, are shown less the
+// common prefix.
+func socketType(eventName string) string {
+	if name, ok := socketTypes[eventName]; ok {
+		return name
+	}
+	return strings.TrimPrefix(eventName, socketPrefix)
+}
+
+// SocketTypes names the rows collected from socket_summary_by_event_name
+// by the type of socket.
+func SocketTypes(rows []Row) []Row {
+	return merge(rows, socketType)
+}
+
+// SocketAddresses names the rows collected from socket_summary_by_instance
+// by the remote IP address of the client connections, merging the
+// connections from the same address. Connections using the unix socket
+// have no address and the server listeners are named by their type.
+// Addresses are anonymised if requested.
+func SocketAddresses(rows []Row) []Row {
+	return merge(rows, func(name string) string {
+		switch {
+		case name == "":
+			return unixSocket
+		case strings.HasPrefix(name, socketPrefix):
+			return socketType(name)
+		default:
+			return utils.Anonymise("ip", name)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package fileinfo
+
+import (
+	"testing"
+
+	"github.com/sjmudd/anonymiser"
+)
+
+func TestSocketAddresses(t *testing.T) {
+	anonymiser.Enable(false)
+
+	rows := []Row{
+		{Name: "10.0.0.1", CountStar: 1},
+		{Name: "10.0.0.2", CountStar: 2},
+		{Name: "10.0.0.1", CountStar: 4},
+		{Name: "", CountStar: 8},
+		{Name: "wait/io/socket/sql/server_tcpip_socket", CountStar: 16},
+		{Name: "wait/io/socket/plugin/other", CountStar: 32},
+	}
+	expected := map[string]uint64{
+		"10.0.0.1":                5,
+		"10.0.0.2":                2,
+		unixSocket:                8,
+		"server listener (tcpip)": 16,
+		"plugin/other":            32,
+	}
+
+	got := SocketAddresses(rows)
+	if len(got) != len(expected) {
+		t.Errorf("SocketAddresses() returned %d rows, expected %d: %v", len(got), len(expected), got)
+	}
+	for _, row := range got {
+		if count, ok := expected[row.Name]; !ok || row.CountStar != count {
+			t.Errorf("SocketAddresses() returned unexpected row %v", row)
+		}
+	}
+}
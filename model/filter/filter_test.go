@@ -68,3 +68,12 @@ func TestExtraSQL(t *testing.T) {
 		}
 	}
 }
+
+func TestExtraSQLFor(t *testing.T) {
+	if result := NewDatabaseFilter("").ExtraSQLFor("SCHEMA_NAME"); result != "" {
+		t.Errorf("DatabaseFilter.ExtraSQLFor() of an empty filter: got %q, wanted empty", result)
+	}
+	if result, expected := NewDatabaseFilter("a,b").ExtraSQLFor("SCHEMA_NAME"), ` AND SCHEMA_NAME IN (?,?)`; result != expected {
+		t.Errorf("DatabaseFilter.ExtraSQLFor() failed. Got: %q, wanted: %q", result, expected)
+	}
+}
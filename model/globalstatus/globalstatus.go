@@ -0,0 +1,49 @@
+package globalstatus
+
+import (
+	"github.com/sjmudd/ps-top/global"
+	"github.com/sjmudd/ps-top/model"
+)
+
+// GlobalStatus holds the rates of the global status counters
+type GlobalStatus struct {
+	*model.BaseCollector[Row, Rows]
+	status *global.Status // created on the first collection, remembering the table used
+}
+
+// NewGlobalStatus creates a new GlobalStatus instance.
+func NewGlobalStatus(cfg model.Config, db model.QueryExecutor) *GlobalStatus {
+	process := func(last, first Rows) (Rows, Row) {
+		results := rates(last, first, cfg.WantRelativeStats())
+		return results, totals(results)
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &GlobalStatus{BaseCollector: bc}
+}
+
+// Collect collects data from the db, updating initial values
+// if needed, and then calculating the rates over the time wanted.
+func (gs *GlobalStatus) Collect() {
+	bc := gs.BaseCollector
+	fetch := func() (Rows, error) {
+		if gs.status == nil {
+			gs.status = global.NewStatus(bc.DB())
+		}
+		return collect(gs.status)
+	}
+	wantRefresh := func() bool {
+		// the server has been restarted if the uptime goes down
+		return (len(bc.First) == 0 && len(bc.Last) > 0) || bc.First.seconds() > bc.Last.seconds()
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats is true for this object
+func (gs GlobalStatus) HaveRelativeStats() bool {
+	return true
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (gs GlobalStatus) WantRelativeStats() bool {
+	return gs.Config().WantRelativeStats()
+}
@@ -0,0 +1,27 @@
+// Package globalstatus contains the routines for managing the rates
+// of a selection of the values in performance_schema.global_status.
+package globalstatus
+
+/*
+// MySQL 8.4
+CREATE TABLE `global_status` (
+  `VARIABLE_NAME` varchar(64) NOT NULL,
+  `VARIABLE_VALUE` varchar(1024) DEFAULT NULL,
+  PRIMARY KEY (`VARIABLE_NAME`)
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+INFORMATION_SCHEMA.GLOBAL_STATUS is used on older servers.
+*/
+
+// Row contains the value of a status variable
+type Row struct {
+	Name      string
+	Value     uint64
+	Gauge     bool    // the value is the current value, not a counter
+	PerSecond float64 // rate of a counter, set when processing
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.Value > 0
+}
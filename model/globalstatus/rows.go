@@ -0,0 +1,121 @@
+package globalstatus
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sjmudd/ps-top/global"
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+)
+
+// uptime is the status variable used to calculate the rates
+const uptime = "Uptime"
+
+// statusVariable is a status variable which is shown
+type statusVariable struct {
+	name  string
+	gauge bool
+}
+
+// statusVariables are the status variables shown, in the default order
+var statusVariables = []statusVariable{
+	{"Questions", false},
+	{"Com_select", false},
+	{"Com_insert", false},
+	{"Com_update", false},
+	{"Com_delete", false},
+	{"Innodb_rows_read", false},
+	{"Innodb_rows_inserted", false},
+	{"Innodb_rows_updated", false},
+	{"Innodb_rows_deleted", false},
+	{"Innodb_data_reads", false},
+	{"Innodb_data_writes", false},
+	{"Bytes_sent", false},
+	{"Bytes_received", false},
+	{"Created_tmp_disk_tables", false},
+	{"Threads_running", true},
+	{"Threads_connected", true},
+}
+
+// Rows contains a set of rows
+type Rows []Row
+
+// totals returns an empty row as the values of different status variables can not be added
+func totals(_ Rows) Row {
+	return Row{Name: "Totals"}
+}
+
+// seconds returns the server uptime held in the rows
+func (rows Rows) seconds() uint64 {
+	for _, row := range rows {
+		if row.Name == uptime {
+			return row.Value
+		}
+	}
+	return 0
+}
+
+// collect returns the status variables shown, and the uptime. No rows
+// are returned if the user may not read the status.
+func collect(status *global.Status) (Rows, error) {
+	log.Printf("collect(?)\n")
+
+	all, err := status.All()
+	if err != nil {
+		if model.IsExpectedError(err) {
+			log.Printf("globalstatus.collect: ignoring expected error: %v", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	wanted := append([]statusVariable{{uptime, true}}, statusVariables...)
+	rows := make(Rows, 0, len(wanted))
+	for _, v := range wanted {
+		value, ok := all[strings.ToLower(v.name)]
+		if !ok {
+			continue // not present in this version of MySQL
+		}
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			log.Printf("collect: unable to parse %s value %q: %v\n", v.name, value, err)
+			continue
+		}
+		rows = append(rows, Row{Name: v.name, Value: n, Gauge: v.gauge})
+	}
+
+	return rows, nil
+}
+
+// rates returns the status variables of last with the values of
+// counters made relative to those in first, if wanted, and their rate
+// per second calculated. The uptime is not included.
+func rates(last, first Rows, relative bool) Rows {
+	seconds := last.seconds()
+	initial := make(map[string]uint64, len(first))
+	if relative {
+		seconds -= first.seconds()
+		for _, row := range first {
+			initial[row.Name] = row.Value
+		}
+	}
+
+	results := make(Rows, 0, len(last))
+	for _, row := range last {
+		if row.Name == uptime {
+			continue
+		}
+		if !row.Gauge {
+			// counters may go down after FLUSH STATUS
+			if value, ok := initial[row.Name]; ok && row.Value >= value {
+				row.Value -= value
+			}
+			if seconds > 0 {
+				row.PerSecond = float64(row.Value) / float64(seconds)
+			}
+		}
+		results = append(results, row)
+	}
+
+	return results
+}
@@ -0,0 +1,54 @@
+package globalstatus
+
+import (
+	"testing"
+
+	"github.com/sjmudd/ps-top/global"
+)
+
+func TestRates(t *testing.T) {
+	first := Rows{
+		{Name: uptime, Value: 100, Gauge: true},
+		{Name: "Questions", Value: 1000},
+		{Name: "Threads_running", Value: 5, Gauge: true},
+	}
+	last := Rows{
+		{Name: uptime, Value: 110, Gauge: true},
+		{Name: "Questions", Value: 1500},
+		{Name: "Threads_running", Value: 3, Gauge: true},
+	}
+
+	tests := []struct {
+		relative  bool
+		questions Row
+	}{
+		{true, Row{Name: "Questions", Value: 500, PerSecond: 50}},
+		{false, Row{Name: "Questions", Value: 1500, PerSecond: 1500.0 / 110}},
+	}
+	for _, test := range tests {
+		got := rates(last, first, test.relative)
+		if len(got) != 2 {
+			t.Fatalf("rates(relative: %v) returned %d rows, want 2 without the uptime", test.relative, len(got))
+		}
+		if got[0] != test.questions {
+			t.Errorf("rates(relative: %v) counter = %+v, want %+v", test.relative, got[0], test.questions)
+		}
+		if want := (Row{Name: "Threads_running", Value: 3, Gauge: true}); got[1] != want {
+			t.Errorf("rates(relative: %v) gauge = %+v, want %+v", test.relative, got[1], want)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	status := global.NewFixedStatus()
+	status.Set(uptime, 100)
+	status.Set("Questions", 1000)
+
+	rows, err := collect(status)
+	if err != nil {
+		t.Fatalf("collect() failed: %v", err)
+	}
+	if len(rows) != 2 || rows.seconds() != 100 {
+		t.Errorf("collect() = %+v, want the uptime and Questions", rows)
+	}
+}
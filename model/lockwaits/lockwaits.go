@@ -0,0 +1,55 @@
+package lockwaits
+
+import (
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+)
+
+// LockWaits holds the InnoDB row lock waits
+type LockWaits struct {
+	*model.BaseCollector[Row, Rows]
+	haveDataLocks *bool // which tables to use, determined on the first collection
+}
+
+// NewLockWaits creates a new LockWaits instance.
+func NewLockWaits(cfg model.Config, db model.QueryExecutor) *LockWaits {
+	process := func(last, _ Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		tot := totals(results)
+		return results, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &LockWaits{BaseCollector: bc}
+}
+
+// Collect collects the lock waits happening now. There is nothing to
+// compare with previous collections so the values are always absolute.
+func (lw *LockWaits) Collect() {
+	bc := lw.BaseCollector
+	fetch := func() (Rows, error) {
+		if lw.haveDataLocks == nil {
+			have, err := HaveDataLocks(bc.DB())
+			if err != nil {
+				log.Printf("LockWaits.Collect: %v", err)
+				return nil, err
+			}
+			lw.haveDataLocks = &have
+		}
+		return collect(bc.DB(), bc.Config().DatabaseFilter(), *lw.haveDataLocks), nil
+	}
+	wantRefresh := func() bool {
+		return true
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats returns false as the lock waits are only seen while waiting
+func (lw LockWaits) HaveRelativeStats() bool {
+	return false
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (lw LockWaits) WantRelativeStats() bool {
+	return lw.Config().WantRelativeStats()
+}
@@ -0,0 +1,86 @@
+// Package lockwaits contains the routines for managing the InnoDB row
+// lock waits, taken from performance_schema.data_lock_waits (MySQL 8.0+)
+// or information_schema.INNODB_LOCK_WAITS (MySQL 5.7).
+package lockwaits
+
+import (
+	"fmt"
+)
+
+/*
+// MySQL 8.4 (columns used)
+CREATE TABLE `data_lock_waits` (
+  `ENGINE` varchar(32) NOT NULL,
+  `REQUESTING_ENGINE_LOCK_ID` varchar(128) NOT NULL,
+  `REQUESTING_ENGINE_TRANSACTION_ID` bigint unsigned DEFAULT NULL,
+  `BLOCKING_ENGINE_LOCK_ID` varchar(128) NOT NULL,
+  `BLOCKING_ENGINE_TRANSACTION_ID` bigint unsigned DEFAULT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+CREATE TABLE `data_locks` (
+  `ENGINE` varchar(32) NOT NULL,
+  `ENGINE_LOCK_ID` varchar(128) NOT NULL,
+  `OBJECT_SCHEMA` varchar(64) DEFAULT NULL,
+  `OBJECT_NAME` varchar(64) DEFAULT NULL,
+  `INDEX_NAME` varchar(64) DEFAULT NULL,
+  `LOCK_TYPE` varchar(32) NOT NULL,
+  `LOCK_MODE` varchar(32) NOT NULL,
+  ...
+  PRIMARY KEY (`ENGINE_LOCK_ID`,`ENGINE`)
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+// MySQL 5.7 (columns used)
+CREATE TEMPORARY TABLE `INNODB_LOCK_WAITS` (
+  `requesting_trx_id` varchar(18) NOT NULL DEFAULT '',
+  `requested_lock_id` varchar(81) NOT NULL DEFAULT '',
+  `blocking_trx_id` varchar(18) NOT NULL DEFAULT '',
+  `blocking_lock_id` varchar(81) NOT NULL DEFAULT ''
+) ENGINE=MEMORY DEFAULT CHARSET=utf8
+
+CREATE TEMPORARY TABLE `INNODB_LOCKS` (
+  `lock_id` varchar(81) NOT NULL DEFAULT '',
+  `lock_mode` varchar(32) NOT NULL DEFAULT '',
+  `lock_type` varchar(32) NOT NULL DEFAULT '',
+  `lock_table` varchar(1024) NOT NULL DEFAULT '',   -- e.g. `db`.`table`
+  `lock_index` varchar(1024) DEFAULT NULL,
+  ...
+) ENGINE=MEMORY DEFAULT CHARSET=utf8
+
+The threads and their statements come from information_schema.INNODB_TRX
+in both versions.
+*/
+
+// Row contains a lock wait: one transaction waiting for a lock held by another
+type Row struct {
+	Table            string // schema.table of the lock
+	Index            string // empty for table locks
+	LockType         string // RECORD or TABLE
+	WaitingLockMode  string // mode of the lock requested
+	BlockingLockMode string // mode of the lock held
+	WaitingID        uint64 // processlist id of the waiting thread
+	WaitingQuery     string // empty if anonymising
+	BlockingID       uint64 // processlist id of the blocking thread
+	BlockingQuery    string // empty if the blocker is idle or if anonymising
+
+	WaitAge uint64 // picoseconds, with a resolution of a second
+}
+
+// Name returns the table and index of the lock
+func (row Row) Name() string {
+	if row.Index == "" {
+		return row.Table
+	}
+	return row.Table + " (" + row.Index + ")"
+}
+
+// Key returns the lock with the waiting and blocking threads, as several
+// threads may wait for the same table or index
+func (row Row) Key() string {
+	return fmt.Sprintf("%s waiting %d blocking %d", row.Name(), row.WaitingID, row.BlockingID)
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.WaitingID > 0
+}
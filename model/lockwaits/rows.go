@@ -0,0 +1,180 @@
+package lockwaits
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/sjmudd/anonymiser"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+	"github.com/sjmudd/ps-top/model/filter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+const (
+	selectCountDataLockWaitsTableSQL = `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = 'performance_schema' and table_name = 'data_lock_waits'`
+
+	// MySQL 8.0+
+	dataLockWaitsSQL = `
+SELECT	rl.OBJECT_SCHEMA,
+	rl.OBJECT_NAME,
+	rl.INDEX_NAME,
+	rl.LOCK_TYPE,
+	rl.LOCK_MODE,
+	bl.LOCK_MODE,
+	wt.trx_mysql_thread_id,
+	wt.trx_query,
+	bt.trx_mysql_thread_id,
+	bt.trx_query,
+	TIMESTAMPDIFF(SECOND, wt.trx_wait_started, NOW())
+FROM	performance_schema.data_lock_waits w
+JOIN	performance_schema.data_locks rl ON rl.ENGINE = w.ENGINE AND rl.ENGINE_LOCK_ID = w.REQUESTING_ENGINE_LOCK_ID
+JOIN	performance_schema.data_locks bl ON bl.ENGINE = w.ENGINE AND bl.ENGINE_LOCK_ID = w.BLOCKING_ENGINE_LOCK_ID
+JOIN	information_schema.INNODB_TRX wt ON wt.trx_id = w.REQUESTING_ENGINE_TRANSACTION_ID
+JOIN	information_schema.INNODB_TRX bt ON bt.trx_id = w.BLOCKING_ENGINE_TRANSACTION_ID
+WHERE	1 = 1`
+
+	// MySQL 5.7, the schema and table are combined in lock_table
+	innodbLockWaitsSQL = `
+SELECT	'',
+	rl.lock_table,
+	rl.lock_index,
+	rl.lock_type,
+	rl.lock_mode,
+	bl.lock_mode,
+	wt.trx_mysql_thread_id,
+	wt.trx_query,
+	bt.trx_mysql_thread_id,
+	bt.trx_query,
+	TIMESTAMPDIFF(SECOND, wt.trx_wait_started, NOW())
+FROM	information_schema.INNODB_LOCK_WAITS w
+JOIN	information_schema.INNODB_LOCKS rl ON rl.lock_id = w.requested_lock_id
+JOIN	information_schema.INNODB_LOCKS bl ON bl.lock_id = w.blocking_lock_id
+JOIN	information_schema.INNODB_TRX wt ON wt.trx_id = w.requesting_trx_id
+JOIN	information_schema.INNODB_TRX bt ON bt.trx_id = w.blocking_trx_id`
+)
+
+// reLockTable matches the `schema`.`table` of INNODB_LOCKS.lock_table
+var reLockTable = regexp.MustCompile("^`([^`]*)`\\.`([^`]*)`")
+
+// Rows contains a set of rows
+type Rows []Row
+
+// totals returns the longest wait as the waits overlap so their sum means nothing
+func totals(rows Rows) Row {
+	total := Row{Table: "Totals"}
+
+	for _, row := range rows {
+		total.WaitAge = max(total.WaitAge, row.WaitAge)
+	}
+
+	return total
+}
+
+// HaveDataLocks returns true if performance_schema.data_lock_waits
+// exists (MySQL 8.0+). If not the information_schema tables are used.
+func HaveDataLocks(db model.QueryExecutor) (bool, error) {
+	var count int
+
+	if err := db.QueryRow(selectCountDataLockWaitsTableSQL).Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return false, fmt.Errorf("COUNT(*) returns no rows: %w", err)
+		}
+		return false, fmt.Errorf("COUNT(*) returns unexpected error: %w", err)
+	}
+
+	log.Printf("HaveDataLocks() returns %d", count)
+	return count == 1, nil
+}
+
+// splitLockTable returns the schema and table of a 5.7 lock_table value
+// such as `db`.`t1` or `db`.`t1` /* Partition `p1` */
+func splitLockTable(lockTable string) (string, string) {
+	m := reLockTable.FindStringSubmatch(lockTable)
+	if m == nil {
+		return "", lockTable
+	}
+	return m[1], m[2]
+}
+
+func collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter, haveDataLocks bool) Rows {
+	var t Rows
+
+	log.Printf("collect(?,%q,%v)\n", databaseFilter, haveDataLocks)
+
+	query := innodbLockWaitsSQL
+	args := []interface{}{}
+
+	// Apply the filter if provided and seems good. The 5.7 tables are
+	// filtered once the schema has been taken from the lock_table.
+	if haveDataLocks {
+		query = dataLockWaitsSQL
+		if len(databaseFilter.Args()) > 0 {
+			query = fmt.Sprintf("%s%s", query, databaseFilter.ExtraSQLFor("rl.OBJECT_SCHEMA"))
+
+			for _, v := range databaseFilter.Args() {
+				args = append(args, v)
+			}
+			log.Printf("apply databaseFilter: sql: %q, args: %+v\n", query, args)
+		}
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		if model.IsAccessDeniedError(err) {
+			log.Printf("lockwaits.collect: ignoring error as the tables can not be read: %v", err)
+			return nil
+		}
+		log.Fatal(err)
+	}
+
+	t = common.Collect(rows, func() (Row, error) {
+		var (
+			schema, table, index        sql.NullString
+			waitingQuery, blockingQuery sql.NullString
+			waitAge                     sql.NullInt64
+			r                           Row
+		)
+		if err := rows.Scan(
+			&schema,
+			&table,
+			&index,
+			&r.LockType,
+			&r.WaitingLockMode,
+			&r.BlockingLockMode,
+			&r.WaitingID,
+			&waitingQuery,
+			&r.BlockingID,
+			&blockingQuery,
+			&waitAge); err != nil {
+			return r, err
+		}
+		s, tbl := schema.String, table.String
+		if !haveDataLocks {
+			s, tbl = splitLockTable(tbl)
+		}
+		r.Table = utils.QualifiedTableName(s, tbl)
+		if index.Valid {
+			r.Index = utils.Anonymise("index", index.String)
+		}
+		if waitAge.Valid && waitAge.Int64 > 0 {
+			r.WaitAge = uint64(waitAge.Int64) * utils.PicosecondsPerSecond
+		}
+		// the statements contain the unquoted names of tables and
+		// columns so can not be anonymised
+		if !anonymiser.Enabled() {
+			r.WaitingQuery = waitingQuery.String
+			r.BlockingQuery = blockingQuery.String
+		}
+		if !haveDataLocks && len(databaseFilter.Args()) > 0 && !slices.Contains(databaseFilter.Args(), s) {
+			return Row{}, nil // removed below
+		}
+		return r, nil
+	})
+
+	return slices.DeleteFunc(t, func(r Row) bool { return !r.HasData() })
+}
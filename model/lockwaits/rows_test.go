@@ -0,0 +1,34 @@
+package lockwaits
+
+import "testing"
+
+func TestSplitLockTable(t *testing.T) {
+	var tests = []struct {
+		lockTable string
+		schema    string
+		table     string
+	}{
+		{"`db`.`t1`", "db", "t1"},
+		{"`db`.`t1` /* Partition `p1` */", "db", "t1"},
+		{"unexpected", "", "unexpected"},
+	}
+
+	for _, test := range tests {
+		schema, table := splitLockTable(test.lockTable)
+		if schema != test.schema || table != test.table {
+			t.Errorf("splitLockTable(%q) failed: expected: %q, %q, got: %q, %q", test.lockTable, test.schema, test.table, schema, table)
+		}
+	}
+}
+
+func TestTotals(t *testing.T) {
+	rows := Rows{
+		{Table: "t1", WaitAge: 3},
+		{Table: "t2", WaitAge: 5},
+		{Table: "t1", WaitAge: 2},
+	}
+
+	if total := totals(rows); total.WaitAge != 5 {
+		t.Errorf("totals() WaitAge = %d, want 5", total.WaitAge)
+	}
+}
@@ -3,31 +3,53 @@
 package memoryusage
 
 import (
+	"slices"
+
 	"github.com/sjmudd/ps-top/model"
 )
 
 // MemoryUsage represents a table of rows
 type MemoryUsage struct {
 	*model.BaseCollector[Row, []Row]
+	collection collection // what was last collected
+	next       collection // what is collected next
 }
 
 // NewMemoryUsage returns a pointer to a MemoryUsage struct
 func NewMemoryUsage(cfg model.Config, db model.QueryExecutor) *MemoryUsage {
+	return newMemoryUsage(cfg, db, global)
+}
+
+// NewMemoryByOwner returns a pointer to a MemoryUsage struct which
+// collects the memory used by each user. NextGrouping changes this to
+// each host, account or thread.
+func NewMemoryByOwner(cfg model.Config, db model.QueryExecutor) *MemoryUsage {
+	return newMemoryUsage(cfg, db, byUser)
+}
+
+func newMemoryUsage(cfg model.Config, db model.QueryExecutor, c collection) *MemoryUsage {
+	mu := &MemoryUsage{collection: c, next: c}
 	process := func(last, _ []Row) ([]Row, Row) {
-		results := make([]Row, len(last))
-		copy(results, last)
+		var results []Row
+		if mu.collection == global {
+			results = make([]Row, len(last))
+			copy(results, last)
+		} else {
+			results = byOwner(last)
+		}
 		tot := totals(results)
 		return results, tot
 	}
-	bc := model.NewBaseCollector[Row, []Row](cfg, db, process)
-	return &MemoryUsage{BaseCollector: bc}
+	mu.BaseCollector = model.NewBaseCollector[Row, []Row](cfg, db, process)
+	return mu
 }
 
 // Collect data from the db, no merging needed
 func (mu *MemoryUsage) Collect() {
 	bc := mu.BaseCollector
 	fetch := func() ([]Row, error) {
-		return collect(bc.DB()), nil
+		mu.collection = mu.next
+		return collect(bc.DB(), mu.collection), nil
 	}
 	wantRefresh := func() bool {
 		// MemoryUsage does not support relative stats, so always refresh baseline to current
@@ -36,6 +58,39 @@ func (mu *MemoryUsage) Collect() {
 	bc.Collect(fetch, wantRefresh)
 }
 
+// NextGrouping changes from collecting the memory of each user to that of
+// each host, account or thread in turn. The new values are used from
+// the next collection. The global memory usage is not grouped, nor is
+// replayed data as it holds the memory of the owners recorded.
+func (mu *MemoryUsage) NextGrouping() {
+	if mu.collection == global || mu.Replaying() {
+		return
+	}
+	i := slices.Index(groupings, mu.next)
+	mu.next = groupings[(i+1)%len(groupings)]
+}
+
+// Grouping returns the name of the owner the memory was last collected
+// for, empty for the global memory usage.
+func (mu *MemoryUsage) Grouping() string {
+	return groupingNames[mu.collection]
+}
+
+// NextGroupingName returns the name of the owner the memory is collected
+// for from the next collection, empty if it has not been changed.
+func (mu *MemoryUsage) NextGroupingName() string {
+	if mu.next == mu.collection {
+		return ""
+	}
+	return groupingNames[mu.next]
+}
+
+// TopEvents returns up to n of the memory events of the given owner
+// using the most memory now.
+func (mu *MemoryUsage) TopEvents(owner string, n int) []Row {
+	return topEvents(mu.Last, owner, n)
+}
+
 // Rows returns the rows we have which are interesting
 func (mu *MemoryUsage) Rows() []Row {
 	return mu.Results
@@ -0,0 +1,110 @@
+package memoryusage
+
+import (
+	"cmp"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// background names the owner of the memory of background threads which
+// have no user or host
+const background = "<background>"
+
+// groupings holds the collections of memory by owner in the order they
+// are cycled through
+var groupings = []collection{byUser, byHost, byAccount, byThread}
+
+// groupingNames holds the name of each collection of memory by owner
+var groupingNames = map[collection]string{
+	byUser:    "user",
+	byHost:    "host",
+	byAccount: "account",
+	byThread:  "thread",
+}
+
+// userName returns the anonymised user name or background if there is none
+func userName(user sql.NullString) string {
+	if !user.Valid {
+		return background
+	}
+	return utils.Anonymise("user", user.String)
+}
+
+// hostName returns the host name or background if there is none
+func hostName(host sql.NullString) string {
+	if !host.Valid {
+		return background
+	}
+	return host.String
+}
+
+// owner returns the name of the owner of the memory collected: the user,
+// host, user@host or the thread id followed by the account of a
+// foreground thread or the name of a background thread.
+func owner(c collection, user, host sql.NullString, threadID sql.NullInt64, threadName sql.NullString) string {
+	switch c {
+	case byUser:
+		return userName(user)
+	case byHost:
+		return hostName(host)
+	case byAccount:
+		if !user.Valid && !host.Valid {
+			return background
+		}
+		return userName(user) + "@" + hostName(host)
+	case byThread:
+		if !user.Valid {
+			return fmt.Sprintf("%d %s", threadID.Int64, strings.TrimPrefix(threadName.String, "thread/"))
+		}
+		return fmt.Sprintf("%d %s@%s", threadID.Int64, userName(user), hostName(host))
+	}
+	return ""
+}
+
+// byOwner merges the rows of each owner, naming the merged row after
+// the owner. The high values are the sum of the high values of each
+// event so may be higher than the memory ever used at once.
+func byOwner(rows []Row) []Row {
+	var merged []Row
+	index := make(map[string]int)
+
+	for _, row := range rows {
+		i, ok := index[row.Owner]
+		if !ok {
+			i = len(merged)
+			index[row.Owner] = i
+			merged = append(merged, Row{Name: row.Owner, Owner: row.Owner})
+		}
+		merged[i].CurrentCountUsed += row.CurrentCountUsed
+		merged[i].HighCountUsed += row.HighCountUsed
+		merged[i].TotalMemoryOps += row.TotalMemoryOps
+		merged[i].CurrentBytesUsed += row.CurrentBytesUsed
+		merged[i].HighBytesUsed += row.HighBytesUsed
+		merged[i].TotalBytesManaged += row.TotalBytesManaged
+	}
+
+	return merged
+}
+
+// topEvents returns up to n of the rows of the given owner using the most
+// memory now.
+func topEvents(rows []Row, owner string, n int) []Row {
+	var events []Row
+	for _, row := range rows {
+		if row.Owner == owner {
+			events = append(events, row)
+		}
+	}
+	slices.SortFunc(events, func(a, b Row) int {
+		if c := cmp.Compare(b.CurrentBytesUsed, a.CurrentBytesUsed); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return events[:min(n, len(events))]
+}
@@ -0,0 +1,102 @@
+package memoryusage
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/sjmudd/anonymiser"
+)
+
+func TestOwner(t *testing.T) {
+	anonymiser.Enable(false)
+
+	user := sql.NullString{String: "app", Valid: true}
+	host := sql.NullString{String: "web1", Valid: true}
+	var none sql.NullString
+	threadID := sql.NullInt64{Int64: 42, Valid: true}
+	threadName := sql.NullString{String: "thread/innodb/io_write_thread", Valid: true}
+
+	var tests = []struct {
+		c          collection
+		user, host sql.NullString
+		expected   string
+	}{
+		{global, none, none, ""},
+		{byUser, user, none, "app"},
+		{byUser, none, none, background},
+		{byHost, none, host, "web1"},
+		{byAccount, user, host, "app@web1"},
+		{byAccount, none, none, background},
+		{byThread, user, host, "42 app@web1"},
+		{byThread, none, none, "42 innodb/io_write_thread"},
+	}
+
+	for _, test := range tests {
+		if got := owner(test.c, test.user, test.host, threadID, threadName); got != test.expected {
+			t.Errorf("owner(%v, %v, %v) = %q, expected %q", test.c, test.user, test.host, got, test.expected)
+		}
+	}
+}
+
+func TestByOwnerAndTopEvents(t *testing.T) {
+	rows := []Row{
+		{Name: "memory/sql/THD::main_mem_root", Owner: "app", CurrentBytesUsed: 100, HighBytesUsed: 200},
+		{Name: "memory/innodb/std", Owner: "app", CurrentBytesUsed: 300, HighBytesUsed: 300},
+		{Name: "memory/sql/String::value", Owner: "app", CurrentBytesUsed: 50, HighBytesUsed: 60},
+		{Name: "memory/sql/THD::main_mem_root", Owner: "root", CurrentBytesUsed: 10, HighBytesUsed: 20},
+	}
+
+	merged := byOwner(rows)
+	expected := []Row{
+		{Name: "app", Owner: "app", CurrentBytesUsed: 450, HighBytesUsed: 560},
+		{Name: "root", Owner: "root", CurrentBytesUsed: 10, HighBytesUsed: 20},
+	}
+	if len(merged) != len(expected) {
+		t.Fatalf("byOwner() returned %v, expected %v", merged, expected)
+	}
+	for i := range merged {
+		if merged[i] != expected[i] {
+			t.Errorf("byOwner()[%d] = %v, expected %v", i, merged[i], expected[i])
+		}
+	}
+
+	top := topEvents(rows, "app", 2)
+	if len(top) != 2 || top[0].Name != "memory/innodb/std" || top[1].Name != "memory/sql/THD::main_mem_root" {
+		t.Errorf("topEvents(app, 2) = %v, expected memory/innodb/std and memory/sql/THD::main_mem_root", top)
+	}
+	if top := topEvents(rows, "nobody", 2); len(top) != 0 {
+		t.Errorf("topEvents(nobody, 2) = %v, expected no rows", top)
+	}
+}
+
+func TestNextGrouping(t *testing.T) {
+	mu := NewMemoryByOwner(nil, nil)
+
+	mu.NextGrouping()
+	if got := mu.Grouping(); got != "user" {
+		t.Errorf("Grouping() before collecting: expected %q, got %q", "user", got)
+	}
+	if mu.next != byHost {
+		t.Errorf("expected the hosts to be collected next, got %v", mu.next)
+	}
+	if got := mu.NextGroupingName(); got != "host" {
+		t.Errorf("NextGroupingName() before collecting: expected %q, got %q", "host", got)
+	}
+
+	// cycling back to the owner collected leaves nothing pending
+	for range len(groupings) - 1 {
+		mu.NextGrouping()
+	}
+	if got := mu.NextGroupingName(); got != "" {
+		t.Errorf("NextGroupingName() after a full cycle: expected %q, got %q", "", got)
+	}
+	mu.NextGrouping()
+
+	// the recorded data is of the owners collected when recording
+	mu.Replay([]Row{}, time.Now())
+	mu.NextGrouping()
+	if mu.next != byHost {
+		t.Errorf("NextGrouping() while replaying: expected no change, got %v", mu.next)
+	}
+}
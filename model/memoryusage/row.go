@@ -1,8 +1,10 @@
 // Package memoryusage contains the library
-// routines for managing memory_summary_global_by_event_name table.
+// routines for managing memory_summary_global_by_event_name table
+// and the memory_summary_by_*_by_event_name tables.
 package memoryusage
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/go-sql-driver/mysql"
@@ -46,9 +48,12 @@ CREATE TABLE `memory_summary_global_by_event_name` (
 
 */
 
-// Row holds a row of data from memory_summary_global_by_event_name
+// Row holds a row of data from memory_summary_global_by_event_name, or
+// from one of the tables which summarise the memory of each user, host,
+// account or thread. The event name is then for the owner given.
 type Row struct {
 	Name              string
+	Owner             string // user, host, account or thread, empty for the global values
 	CurrentCountUsed  int64
 	HighCountUsed     int64
 	TotalMemoryOps    int64
@@ -102,24 +107,55 @@ func sqlErrorHandler(err error) bool {
 	return ignore
 }
 
+// collection selects what is collected
+type collection int
+
+const (
+	global    collection = iota // memory_summary_global_by_event_name
+	byUser                      // memory_summary_by_user_by_event_name
+	byHost                      // memory_summary_by_host_by_event_name
+	byAccount                   // memory_summary_by_account_by_event_name
+	byThread                    // memory_summary_by_thread_by_event_name
+)
+
+// ownerColumns holds the columns which identify the owner of the memory
+// for each collection: the user, host, thread id and thread name.
+var ownerColumns = map[collection]string{
+	global:    `NULL, NULL, NULL, NULL`,
+	byUser:    `USER, NULL, NULL, NULL`,
+	byHost:    `NULL, HOST, NULL, NULL`,
+	byAccount: `USER, HOST, NULL, NULL`,
+	byThread:  `PROCESSLIST_USER, PROCESSLIST_HOST, THREAD_ID, NAME`,
+}
+
+// collectTable holds the table collected from for each collection
+var collectTable = map[collection]string{
+	global:    `memory_summary_global_by_event_name`,
+	byUser:    `memory_summary_by_user_by_event_name`,
+	byHost:    `memory_summary_by_host_by_event_name`,
+	byAccount: `memory_summary_by_account_by_event_name`,
+	byThread:  `memory_summary_by_thread_by_event_name JOIN threads USING (THREAD_ID)`,
+}
+
 // Select the raw data from the database
-func collect(db model.QueryExecutor) []Row {
+func collect(db model.QueryExecutor, c collection) []Row {
 	var t []Row
 	var skip bool
 
-	sql := `-- memoryusage
-SELECT	EVENT_NAME                                           AS eventName,
+	query := `-- memoryusage
+SELECT	` + ownerColumns[c] + `,
+	EVENT_NAME                                           AS eventName,
 	CURRENT_COUNT_USED                                   AS currentCountUsed,
 	HIGH_COUNT_USED                                      AS highCountUsed,
 	CURRENT_NUMBER_OF_BYTES_USED                         AS currentBytesUsed,
 	HIGH_NUMBER_OF_BYTES_USED                            AS highBytesUsed,
 	COUNT_ALLOC + COUNT_FREE                             AS totalMemoryOps,
 	SUM_NUMBER_OF_BYTES_ALLOC + SUM_NUMBER_OF_BYTES_FREE AS totalBytesManaged
-FROM	memory_summary_global_by_event_name
+FROM	` + collectTable[c] + `
 WHERE	HIGH_COUNT_USED > 0`
 
-	log.Println("Querying db:", sql)
-	rows, err := db.Query(sql)
+	log.Println("Querying db:", query)
+	rows, err := db.Query(query)
 	if err != nil {
 		// FIXME - This should be caught by the validateViews() upstream but isn't for initial
 		// FIXME   table collection. I'm waiting to clean up by splitting views and models but
@@ -131,7 +167,13 @@ WHERE	HIGH_COUNT_USED > 0`
 	if !skip {
 		for rows.Next() {
 			var r Row
+			var user, host, threadName sql.NullString
+			var threadID sql.NullInt64
 			if err := rows.Scan(
+				&user,
+				&host,
+				&threadID,
+				&threadName,
 				&r.Name,
 				&r.CurrentCountUsed,
 				&r.HighCountUsed,
@@ -141,6 +183,7 @@ WHERE	HIGH_COUNT_USED > 0`
 				&r.TotalBytesManaged); err != nil {
 				log.Fatalf("collect: rows.Scan() failed: %+v", err)
 			}
+			r.Owner = owner(c, user, host, threadID, threadName)
 			t = append(t, r)
 		}
 		if err := rows.Err(); err != nil {
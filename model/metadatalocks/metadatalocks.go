@@ -0,0 +1,46 @@
+package metadatalocks
+
+import (
+	"github.com/sjmudd/ps-top/model"
+)
+
+// MetadataLocks holds the metadata locks of each object
+type MetadataLocks struct {
+	*model.BaseCollector[Row, Rows]
+}
+
+// NewMetadataLocks creates a new MetadataLocks instance.
+func NewMetadataLocks(cfg model.Config, db model.QueryExecutor) *MetadataLocks {
+	process := func(last, _ Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		tot := totals(results)
+		return results, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &MetadataLocks{BaseCollector: bc}
+}
+
+// Collect collects the metadata locks held and waited for now. There is
+// nothing to compare with previous collections so the values are always
+// absolute.
+func (ml *MetadataLocks) Collect() {
+	bc := ml.BaseCollector
+	fetch := func() (Rows, error) {
+		return collect(bc.DB(), bc.Config().DatabaseFilter()), nil
+	}
+	wantRefresh := func() bool {
+		return true
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats returns false as the locks are only seen while held
+func (ml MetadataLocks) HaveRelativeStats() bool {
+	return false
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (ml MetadataLocks) WantRelativeStats() bool {
+	return ml.Config().WantRelativeStats()
+}
@@ -0,0 +1,54 @@
+// Package metadatalocks contains the routines for managing
+// performance_schema.metadata_locks.
+package metadatalocks
+
+/*
+// MySQL 8.4 (columns used)
+CREATE TABLE `metadata_locks` (
+  `OBJECT_TYPE` varchar(64) NOT NULL,
+  `OBJECT_SCHEMA` varchar(64) DEFAULT NULL,
+  `OBJECT_NAME` varchar(64) DEFAULT NULL,
+  `LOCK_TYPE` varchar(32) NOT NULL,
+  `LOCK_STATUS` varchar(32) NOT NULL,
+  `OWNER_THREAD_ID` bigint unsigned DEFAULT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+The wait/lock/metadata/sql/mdl instrument must be enabled for the
+table to be populated. How long the pending locks have been waited for
+is taken from the PROCESSLIST_TIME of the owner in
+performance_schema.threads.
+*/
+
+// Row contains the metadata locks granted and pending on an object
+type Row struct {
+	ObjectType   string // e.g. TABLE, SCHEMA or GLOBAL
+	Object       string // schema.name of the object, empty if it has no name
+	GrantedTypes string // the different types of granted lock
+	Owners       string // processlist ids of the threads owning granted locks
+	PendingTypes string // the different types of pending lock
+	Waiters      string // processlist ids of the threads waiting for the locks
+
+	Granted uint64
+	Pending uint64
+	WaitAge uint64 // longest wait of the pending locks in picoseconds, with a resolution of a second
+}
+
+// Name returns the name of the object, or its type if it has no name
+func (row Row) Name() string {
+	if row.Object == "" {
+		return row.ObjectType
+	}
+	return row.Object
+}
+
+// Key uniquely identifies the object locked, as objects of different
+// types may have the same name
+func (row Row) Key() string {
+	return row.ObjectType + "\x00" + row.Object
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.Granted+row.Pending > 0
+}
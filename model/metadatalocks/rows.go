@@ -0,0 +1,145 @@
+package metadatalocks
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+	"github.com/sjmudd/ps-top/model/filter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// Rows contains a set of rows
+type Rows []Row
+
+// lock is a single row of metadata_locks
+type lock struct {
+	objectType string
+	object     string
+	lockType   string
+	pending    bool
+	owner      uint64 // processlist id, 0 for background threads
+	time       uint64 // seconds the owner has been in its current state
+}
+
+func totals(rows Rows) Row {
+	total := Row{Object: "Totals"}
+
+	for _, row := range rows {
+		total.Granted += row.Granted
+		total.Pending += row.Pending
+		total.WaitAge = max(total.WaitAge, row.WaitAge)
+	}
+
+	return total
+}
+
+// addTo adds a value to a comma separated list of distinct values
+func addTo(list *string, value string) {
+	if value == "" {
+		return
+	}
+	values := strings.Split(*list, ",")
+	if *list == "" {
+		values = nil
+	}
+	if !slices.Contains(values, value) {
+		*list = strings.Join(append(values, value), ",")
+	}
+}
+
+// byObject combines the locks on each object into a single row
+func byObject(locks []lock) Rows {
+	var rows Rows
+	index := make(map[string]int)
+
+	for _, l := range locks {
+		r := Row{ObjectType: l.objectType, Object: l.object}
+		i, ok := index[r.Key()]
+		if !ok {
+			i = len(rows)
+			index[r.Key()] = i
+			rows = append(rows, r)
+		}
+		row := &rows[i]
+
+		var owner string
+		if l.owner > 0 {
+			owner = strconv.FormatUint(l.owner, 10)
+		}
+		if l.pending {
+			row.Pending++
+			addTo(&row.PendingTypes, l.lockType)
+			addTo(&row.Waiters, owner)
+			row.WaitAge = max(row.WaitAge, l.time*utils.PicosecondsPerSecond)
+		} else {
+			row.Granted++
+			addTo(&row.GrantedTypes, l.lockType)
+			addTo(&row.Owners, owner)
+		}
+	}
+
+	return rows
+}
+
+func collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter) Rows {
+	log.Printf("collect(?,%q)\n", databaseFilter)
+
+	query := `SELECT m.OBJECT_TYPE, m.OBJECT_SCHEMA, m.OBJECT_NAME, m.LOCK_TYPE, m.LOCK_STATUS, t.PROCESSLIST_ID, t.PROCESSLIST_TIME FROM performance_schema.metadata_locks m JOIN performance_schema.threads t ON t.THREAD_ID = m.OWNER_THREAD_ID WHERE m.LOCK_STATUS IN ('GRANTED','PENDING') AND (t.PROCESSLIST_ID IS NULL OR t.PROCESSLIST_ID <> CONNECTION_ID())`
+	args := []interface{}{}
+
+	// Apply the filter if provided and seems good.
+	if len(databaseFilter.Args()) > 0 {
+		query = fmt.Sprintf("%s%s", query, databaseFilter.ExtraSQLFor("m.OBJECT_SCHEMA"))
+
+		for _, v := range databaseFilter.Args() {
+			args = append(args, v)
+		}
+		log.Printf("apply databaseFilter: sql: %q, args: %+v\n", query, args)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		// metadata_locks does not exist in MySQL 5.6 and older MariaDB
+		if model.IsMissingError(err) {
+			log.Printf("metadatalocks.collect: ignoring expected error: %v", err)
+			return nil
+		}
+		log.Fatal(err)
+	}
+
+	locks := common.Collect(rows, func() (lock, error) {
+		var (
+			schema, name sql.NullString
+			status       string
+			owner, time  sql.NullInt64
+			l            lock
+		)
+		if err := rows.Scan(
+			&l.objectType,
+			&schema,
+			&name,
+			&l.lockType,
+			&status,
+			&owner,
+			&time); err != nil {
+			return l, err
+		}
+		l.object = utils.QualifiedTableName(schema.String, name.String)
+		l.pending = status == "PENDING"
+		if owner.Valid && owner.Int64 > 0 {
+			l.owner = uint64(owner.Int64)
+		}
+		if time.Valid && time.Int64 > 0 {
+			l.time = uint64(time.Int64)
+		}
+		return l, nil
+	})
+
+	return byObject(locks)
+}
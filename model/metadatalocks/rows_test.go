@@ -0,0 +1,38 @@
+package metadatalocks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sjmudd/ps-top/utils"
+)
+
+func TestByObject(t *testing.T) {
+	locks := []lock{
+		{objectType: "TABLE", object: "db.t1", lockType: "SHARED_READ", owner: 10, time: 100},
+		{objectType: "TABLE", object: "db.t1", lockType: "SHARED_READ", owner: 11, time: 50},
+		{objectType: "TABLE", object: "db.t1", lockType: "EXCLUSIVE", pending: true, owner: 12, time: 30},
+		{objectType: "TABLE", object: "db.t1", lockType: "SHARED_READ", pending: true, owner: 13, time: 20},
+		{objectType: "GLOBAL", lockType: "INTENTION_EXCLUSIVE", owner: 12},
+		{objectType: "TABLE", object: "db.t2", lockType: "SHARED_WRITE"}, // background thread
+	}
+	expected := Rows{
+		{
+			ObjectType:   "TABLE",
+			Object:       "db.t1",
+			GrantedTypes: "SHARED_READ",
+			Owners:       "10,11",
+			PendingTypes: "EXCLUSIVE,SHARED_READ",
+			Waiters:      "12,13",
+			Granted:      2,
+			Pending:      2,
+			WaitAge:      30 * utils.PicosecondsPerSecond,
+		},
+		{ObjectType: "GLOBAL", GrantedTypes: "INTENTION_EXCLUSIVE", Owners: "12", Granted: 1},
+		{ObjectType: "TABLE", Object: "db.t2", GrantedTypes: "SHARED_WRITE", Granted: 1},
+	}
+
+	if got := byObject(locks); !reflect.DeepEqual(got, expected) {
+		t.Errorf("byObject() failed:\nexpected: %+v\ngot:      %+v", expected, got)
+	}
+}
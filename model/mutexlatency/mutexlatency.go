@@ -7,13 +7,16 @@ import (
 	"github.com/sjmudd/ps-top/model/common"
 )
 
-// MutexLatency holds a table of rows
+// MutexLatency holds a table of rows of the synchronisation instruments:
+// mutexes, rwlocks, prlocks, sxlocks and conds.
 type MutexLatency struct {
 	*model.BaseCollector[Row, Rows]
+	grouping grouping
 }
 
 // NewMutexLatency creates a new MutexLatency instance.
 func NewMutexLatency(cfg model.Config, db model.QueryExecutor) *MutexLatency {
+	ml := &MutexLatency{}
 	process := func(last, first Rows) (Rows, Row) {
 		results := make(Rows, len(last))
 		copy(results, last)
@@ -23,11 +26,12 @@ func NewMutexLatency(cfg model.Config, db model.QueryExecutor) *MutexLatency {
 				func(r *Row, o Row) { r.subtract(o) },
 			)
 		}
+		results = results.group(ml.grouping)
 		tot := totals(results)
 		return results, tot
 	}
-	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
-	return &MutexLatency{BaseCollector: bc}
+	ml.BaseCollector = model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return ml
 }
 
 // Collect collects data from the db, updating first
@@ -44,6 +48,24 @@ func (ml *MutexLatency) Collect() {
 	bc.Collect(fetch, wantRefresh)
 }
 
+// NextGrouping changes to the next way of grouping the rows: by
+// instrument, by type of primitive or by subsystem.
+func (ml *MutexLatency) NextGrouping() {
+	ml.grouping = (ml.grouping + 1) % numGroupings
+	ml.Reprocess()
+}
+
+// Grouping returns the name of the current grouping.
+func (ml *MutexLatency) Grouping() string {
+	return groupingNames[ml.grouping]
+}
+
+// Group returns the raw rows grouped in the current way so that the
+// values of a grouped row can be found.
+func (ml *MutexLatency) Group(rows []Row) []Row {
+	return Rows(rows).group(ml.grouping)
+}
+
 // HaveRelativeStats is true for this object
 func (ml MutexLatency) HaveRelativeStats() bool {
 	return true
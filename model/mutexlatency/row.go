@@ -3,6 +3,9 @@
 package mutexlatency
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/sjmudd/ps-top/model/common"
 )
 
@@ -28,6 +31,29 @@ type Row struct {
 	CountStar    uint64
 }
 
+// primitives holds the synchronisation primitives which start the names of the instruments
+var primitives = []string{"mutex", "rwlock", "prlock", "sxlock", "cond"}
+
+// Type returns the synchronisation primitive of the row: mutex, rwlock,
+// prlock, sxlock or cond. It is the first part of the name, or empty if
+// the row has no single primitive, e.g. when grouped by subsystem.
+func (row Row) Type() string {
+	primitive, _, _ := strings.Cut(row.Name, "/")
+	if !slices.Contains(primitives, primitive) {
+		return ""
+	}
+	return primitive
+}
+
+// subsystem returns the subsystem of the instrument, e.g. innodb, sql or mysys
+func (row Row) subsystem() string {
+	parts := strings.SplitN(row.Name, "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
 // subtract the countable values in one row from another
 func (row *Row) subtract(other Row) {
 	common.SubtractCounts(&row.SumTimerWait, &row.CountStar, other.SumTimerWait, other.CountStar, row, other)
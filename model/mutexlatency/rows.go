@@ -3,11 +3,30 @@
 package mutexlatency
 
 import (
+	"strings"
+
 	"github.com/sjmudd/ps-top/log"
 	"github.com/sjmudd/ps-top/model"
 	"github.com/sjmudd/ps-top/model/common"
 )
 
+// grouping selects how the rows are grouped together
+type grouping int
+
+const (
+	byInstrument grouping = iota // each instrument, the default
+	byType                       // each synchronisation primitive
+	bySubsystem                  // each subsystem, e.g. innodb, whatever the primitive
+	numGroupings
+)
+
+// groupingNames holds the name of each grouping
+var groupingNames = map[grouping]string{
+	byInstrument: "instrument",
+	byType:       "type",
+	bySubsystem:  "subsystem",
+}
+
 // Rows contains a slice of Row
 type Rows []Row
 
@@ -22,19 +41,43 @@ func totals(rows Rows) Row {
 	return total
 }
 
+// group merges the rows using the given grouping
+func (rows Rows) group(g grouping) Rows {
+	if g == byInstrument {
+		return rows
+	}
+
+	var grouped Rows
+	index := make(map[string]int)
+	for _, row := range rows {
+		name := row.Type()
+		if g == bySubsystem {
+			name = row.subsystem()
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(grouped)
+			index[name] = i
+			grouped = append(grouped, Row{Name: name})
+		}
+		grouped[i].SumTimerWait += row.SumTimerWait
+		grouped[i].CountStar += row.CountStar
+	}
+
+	return grouped
+}
+
 func collect(db model.QueryExecutor) Rows {
 	const prefix = "wait/synch/"
 	var t Rows
 
 	// Collect all information even if it's mainly empty as we may reference it later
+	// This includes the mutex, rwlock, prlock, sxlock and cond instruments.
 	sql := `
 SELECT EVENT_NAME, SUM_TIMER_WAIT, COUNT_STAR
 FROM events_waits_summary_global_by_event_name
 WHERE SUM_TIMER_WAIT > 0
-AND (
-	EVENT_NAME LIKE 'wait/synch/mutex/%' OR
-	EVENT_NAME LIKE 'wait/synch/sxlock/innodb/%' -- FIX: this view name may need changing as it's not a mutex
-)
+AND EVENT_NAME LIKE 'wait/synch/%'
 `
 
 	rows, err := db.Query(sql)
@@ -52,9 +95,7 @@ AND (
 		}
 
 		// Trim off the leading prefix characters
-		if len(r.Name) >= len(prefix) {
-			r.Name = r.Name[len(prefix):]
-		}
+		r.Name = strings.TrimPrefix(r.Name, prefix)
 
 		// Collect all information even if it's mainly empty as we may reference it later
 		return r, nil
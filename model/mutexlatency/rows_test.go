@@ -0,0 +1,47 @@
+package mutexlatency
+
+import (
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	rows := Rows{
+		{Name: "mutex/innodb/buf_pool_mutex", SumTimerWait: 1, CountStar: 10},
+		{Name: "mutex/sql/LOCK_open", SumTimerWait: 2, CountStar: 20},
+		{Name: "rwlock/innodb/btr_search_latch", SumTimerWait: 4, CountStar: 40},
+		{Name: "mutex/innodb/log_sys_mutex", SumTimerWait: 8, CountStar: 80},
+		{Name: "cond/sql/COND_server_started", SumTimerWait: 16, CountStar: 160},
+	}
+
+	var tests = []struct {
+		grouping grouping
+		expected Rows
+	}{
+		{byInstrument, rows},
+		{byType, Rows{
+			{Name: "mutex", SumTimerWait: 11, CountStar: 110},
+			{Name: "rwlock", SumTimerWait: 4, CountStar: 40},
+			{Name: "cond", SumTimerWait: 16, CountStar: 160},
+		}},
+		{bySubsystem, Rows{
+			{Name: "innodb", SumTimerWait: 13, CountStar: 130},
+			{Name: "sql", SumTimerWait: 18, CountStar: 180},
+		}},
+	}
+
+	for _, test := range tests {
+		got := rows.group(test.grouping)
+		if len(got) != len(test.expected) {
+			t.Errorf("group(%v) returned %d rows, expected %d: %v", groupingNames[test.grouping], len(got), len(test.expected), got)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("group(%v)[%d] = %v, expected %v", groupingNames[test.grouping], i, got[i], test.expected[i])
+			}
+		}
+		if totals(got) != totals(rows) {
+			t.Errorf("group(%v) totals %v, expected %v", groupingNames[test.grouping], totals(got), totals(rows))
+		}
+	}
+}
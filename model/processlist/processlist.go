@@ -5,9 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 
-	"github.com/sjmudd/anonymiser"
 	"github.com/sjmudd/ps-top/log"
 	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/utils"
 )
 
 const selectCountPSProcesslistTableSQL = `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = 'performance_schema' and table_name = 'processlist'`
@@ -100,7 +100,7 @@ func Collect(db model.QueryExecutor) []Row {
 
 		// be verbose for debugging.
 		u := user.String
-		a := anonymiser.Anonymise("user", user.String)
+		a := utils.Anonymise("user", user.String)
 		log.Println("user:", u, ", anonymised:", a)
 		r.User = a
 		r.Host = host.String
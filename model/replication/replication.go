@@ -0,0 +1,46 @@
+package replication
+
+import (
+	"github.com/sjmudd/ps-top/model"
+)
+
+// Replication holds the status of the replication threads of each channel
+type Replication struct {
+	*model.BaseCollector[Row, Rows]
+}
+
+// NewReplication creates a new Replication instance.
+func NewReplication(cfg model.Config, db model.QueryExecutor) *Replication {
+	process := func(last, _ Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		tot := totals(results)
+		return results, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &Replication{BaseCollector: bc}
+}
+
+// Collect collects the current status of the replication threads.
+// There is nothing to compare with previous collections so the values
+// are always absolute.
+func (r *Replication) Collect() {
+	bc := r.BaseCollector
+	fetch := func() (Rows, error) {
+		return collect(bc.DB()), nil
+	}
+	wantRefresh := func() bool {
+		return true
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats returns false as the status is only of the current state
+func (r Replication) HaveRelativeStats() bool {
+	return false
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (r Replication) WantRelativeStats() bool {
+	return r.Config().WantRelativeStats()
+}
@@ -0,0 +1,91 @@
+// Package replication contains the routines for managing the status of
+// the replication receiver, applier and worker threads, taken from
+// performance_schema.replication_connection_status,
+// replication_applier_status, replication_applier_status_by_coordinator
+// and replication_applier_status_by_worker (MySQL 8.0+).
+package replication
+
+import "fmt"
+
+/*
+// MySQL 8.4 (columns used)
+CREATE TABLE `replication_connection_status` (
+  `CHANNEL_NAME` char(64) NOT NULL,
+  `SERVICE_STATE` enum('ON','OFF','CONNECTING') NOT NULL,
+  `LAST_ERROR_NUMBER` int NOT NULL,
+  `LAST_ERROR_MESSAGE` varchar(1024) NOT NULL,
+  `RECEIVED_TRANSACTION_SET` longtext NOT NULL,
+  `QUEUEING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP` timestamp(6) NOT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+CREATE TABLE `replication_applier_status` (
+  `CHANNEL_NAME` char(64) NOT NULL,
+  `SERVICE_STATE` enum('ON','OFF') NOT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+CREATE TABLE `replication_applier_status_by_coordinator` (
+  `CHANNEL_NAME` char(64) NOT NULL,
+  `LAST_ERROR_NUMBER` int NOT NULL,
+  `LAST_ERROR_MESSAGE` varchar(1024) NOT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+CREATE TABLE `replication_applier_status_by_worker` (
+  `CHANNEL_NAME` char(64) NOT NULL,
+  `WORKER_ID` bigint unsigned NOT NULL,
+  `SERVICE_STATE` enum('ON','OFF') NOT NULL,
+  `LAST_ERROR_NUMBER` int NOT NULL,
+  `LAST_ERROR_MESSAGE` varchar(1024) NOT NULL,
+  `LAST_APPLIED_TRANSACTION` char(57) DEFAULT NULL,
+  `LAST_APPLIED_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP` timestamp(6) NOT NULL,
+  `LAST_APPLIED_TRANSACTION_START_APPLY_TIMESTAMP` timestamp(6) NOT NULL,
+  `LAST_APPLIED_TRANSACTION_END_APPLY_TIMESTAMP` timestamp(6) NOT NULL,
+  `APPLYING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP` timestamp(6) NOT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+Unset timestamps are 0000-00-00 00:00:00.000000.
+*/
+
+// The threads shown for each channel
+const (
+	receiverThread = "receiver"
+	applierThread  = "applier"
+	workerThread   = "worker"
+)
+
+// Row contains the status of a replication thread of a channel
+type Row struct {
+	Channel         string // empty for the default channel
+	Thread          string // receiver, applier or worker <id>
+	State           string // ON, OFF or CONNECTING
+	LastErrorNumber uint64
+	LastError       string
+	Transactions    string // receiver: GTIDs received, applier: GTIDs executed, worker: last transaction applied
+
+	ApplyTime uint64 // worker: picoseconds taken to apply its last transaction
+	Lag       uint64 // picoseconds since the original commit of the transaction being received or applied
+}
+
+// Name returns the channel and thread
+func (row Row) Name() string {
+	if row.Channel == "" {
+		return row.Thread
+	}
+	return row.Channel + " " + row.Thread
+}
+
+// ErrorMessage returns the last error of the thread, if any
+func (row Row) ErrorMessage() string {
+	if row.LastErrorNumber == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d: %s", row.LastErrorNumber, row.LastError)
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.Thread != ""
+}
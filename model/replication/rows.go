@@ -0,0 +1,124 @@
+package replication
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+)
+
+const (
+	// picoseconds from the microseconds between two timestamps, 0 if the first is not set
+	picosecondsSince = "IF(%[1]s = 0, 0, TIMESTAMPDIFF(MICROSECOND, %[1]s, %[2]s) * 1000000)"
+)
+
+var (
+	receiverSQL = `
+SELECT	CHANNEL_NAME,
+	SERVICE_STATE,
+	LAST_ERROR_NUMBER,
+	LAST_ERROR_MESSAGE,
+	RECEIVED_TRANSACTION_SET,
+	0,
+	` + fmt.Sprintf(picosecondsSince, "QUEUEING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP", "NOW(6)") + `
+FROM	performance_schema.replication_connection_status`
+
+	applierSQL = `
+SELECT	a.CHANNEL_NAME,
+	a.SERVICE_STATE,
+	COALESCE(c.LAST_ERROR_NUMBER, 0),
+	COALESCE(c.LAST_ERROR_MESSAGE, ''),
+	@@GLOBAL.gtid_executed,
+	0,
+	0
+FROM	performance_schema.replication_applier_status a
+LEFT JOIN performance_schema.replication_applier_status_by_coordinator c ON c.CHANNEL_NAME = a.CHANNEL_NAME`
+
+	workerSQL = `
+SELECT	CHANNEL_NAME,
+	SERVICE_STATE,
+	LAST_ERROR_NUMBER,
+	LAST_ERROR_MESSAGE,
+	COALESCE(LAST_APPLIED_TRANSACTION, ''),
+	` + fmt.Sprintf(picosecondsSince, "LAST_APPLIED_TRANSACTION_START_APPLY_TIMESTAMP", "LAST_APPLIED_TRANSACTION_END_APPLY_TIMESTAMP") + `,
+	IF(APPLYING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP = 0,
+	` + fmt.Sprintf(picosecondsSince, "LAST_APPLIED_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP", "LAST_APPLIED_TRANSACTION_END_APPLY_TIMESTAMP") + `,
+	` + fmt.Sprintf(picosecondsSince, "APPLYING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP", "NOW(6)") + `),
+	WORKER_ID
+FROM	performance_schema.replication_applier_status_by_worker`
+)
+
+// Rows contains a set of rows
+type Rows []Row
+
+func totals(rows Rows) Row {
+	total := Row{Thread: "Totals"}
+
+	for _, row := range rows {
+		total.ApplyTime = max(total.ApplyTime, row.ApplyTime)
+		total.Lag = max(total.Lag, row.Lag)
+	}
+
+	return total
+}
+
+// collectThreads returns the status of the threads returned by query,
+// naming them with thread. The query for the workers returns the worker
+// id as an extra column.
+func collectThreads(db model.QueryExecutor, query, thread string) Rows {
+	rows, err := db.Query(query)
+	if err != nil {
+		// the tables or columns are missing before MySQL 8.0
+		if model.IsMissingError(err) {
+			log.Printf("replication.collectThreads: ignoring expected error: %v", err)
+			return nil
+		}
+		log.Fatal(err)
+	}
+
+	return common.Collect(rows, func() (Row, error) {
+		var (
+			lag, applyTime sql.NullInt64
+			workerID       uint64
+			r              = Row{Thread: thread}
+		)
+		dest := []any{
+			&r.Channel,
+			&r.State,
+			&r.LastErrorNumber,
+			&r.LastError,
+			&r.Transactions,
+			&applyTime,
+			&lag,
+		}
+		if thread == workerThread {
+			dest = append(dest, &workerID)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return r, err
+		}
+		if thread == workerThread {
+			r.Thread = fmt.Sprintf("%s %d", workerThread, workerID)
+		}
+		if applyTime.Valid && applyTime.Int64 > 0 {
+			r.ApplyTime = uint64(applyTime.Int64)
+		}
+		if lag.Valid && lag.Int64 > 0 {
+			r.Lag = uint64(lag.Int64)
+		}
+		return r, nil
+	})
+}
+
+func collect(db model.QueryExecutor) Rows {
+	log.Printf("collect(?)\n")
+
+	var t Rows
+	t = append(t, collectThreads(db, receiverSQL, receiverThread)...)
+	t = append(t, collectThreads(db, applierSQL, applierThread)...)
+	t = append(t, collectThreads(db, workerSQL, workerThread)...)
+
+	return t
+}
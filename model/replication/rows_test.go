@@ -0,0 +1,16 @@
+package replication
+
+import "testing"
+
+func TestRowNameAndError(t *testing.T) {
+	row := Row{Channel: "ch1", Thread: "worker 2", LastErrorNumber: 1062, LastError: "Duplicate entry"}
+	if got := row.Name(); got != "ch1 worker 2" {
+		t.Errorf("Name() = %q, want %q", got, "ch1 worker 2")
+	}
+	if got := row.ErrorMessage(); got != "1062: Duplicate entry" {
+		t.Errorf("ErrorMessage() = %q, want %q", got, "1062: Duplicate entry")
+	}
+	if got := (Row{Thread: receiverThread}).Name(); got != receiverThread {
+		t.Errorf("Name() of the default channel = %q, want %q", got, receiverThread)
+	}
+}
@@ -0,0 +1,73 @@
+// Package runningstatements contains the routines for managing the
+// statements currently running, taken from performance_schema.threads
+// and performance_schema.events_statements_current.
+package runningstatements
+
+import (
+	"fmt"
+)
+
+/*
+// MySQL 8.4 (columns used)
+CREATE TABLE `threads` (
+  `THREAD_ID` bigint unsigned NOT NULL,
+  `TYPE` varchar(10) NOT NULL,
+  `PROCESSLIST_ID` bigint unsigned DEFAULT NULL,
+  `PROCESSLIST_USER` varchar(32) DEFAULT NULL,
+  `PROCESSLIST_HOST` varchar(255) CHARACTER SET ascii COLLATE ascii_general_ci DEFAULT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb3
+
+CREATE TABLE `events_statements_current` (
+  `THREAD_ID` bigint unsigned NOT NULL,
+  `EVENT_ID` bigint unsigned NOT NULL,
+  `END_EVENT_ID` bigint unsigned DEFAULT NULL,
+  `EVENT_NAME` varchar(128) NOT NULL,
+  `TIMER_WAIT` bigint unsigned DEFAULT NULL,
+  `LOCK_TIME` bigint unsigned NOT NULL,
+  `SQL_TEXT` longtext,
+  `CURRENT_SCHEMA` varchar(64) DEFAULT NULL,
+  `ROWS_SENT` bigint unsigned NOT NULL,
+  `ROWS_EXAMINED` bigint unsigned NOT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb3
+
+While a statement is running TIMER_WAIT is the time it has taken so far.
+*/
+
+// Row contains a statement which is currently running
+type Row struct {
+	ThreadID      uint64
+	EventID       uint64 // distinguishes the statements of a thread running a stored program
+	ProcesslistID uint64
+	User          string
+	Host          string
+	Schema        string // default database of the statement, empty if none
+	EventName     string // e.g. statement/sql/select
+	Text          string // empty if anonymising as the text is not normalised
+
+	TimerWait    uint64 // time the statement has been running
+	LockTime     uint64
+	RowsExamined uint64
+	RowsSent     uint64
+}
+
+// Name returns the statement text or the statement's event name if there is no text
+func (row Row) Name() string {
+	if row.Text == "" {
+		return row.EventName
+	}
+	return row.Text
+}
+
+// Key returns the thread and event of the statement, which unlike its text
+// are unique. A thread running a stored program has a statement for each
+// nesting level so the thread alone is not enough.
+func (row Row) Key() string {
+	return fmt.Sprintf("thread %d event %d", row.ThreadID, row.EventID)
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.ThreadID > 0
+}
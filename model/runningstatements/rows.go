@@ -0,0 +1,126 @@
+package runningstatements
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/anonymiser"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+	"github.com/sjmudd/ps-top/model/filter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// the consumers which must be enabled for events_statements_current to be populated
+const selectEnabledConsumersSQL = `SELECT COUNT(*) FROM performance_schema.setup_consumers WHERE NAME IN ('global_instrumentation','thread_instrumentation','events_statements_current') AND ENABLED = 'YES'`
+
+// Rows contains a set of rows
+type Rows []Row
+
+func totals(rows Rows) Row {
+	total := Row{Text: "Totals"}
+
+	for _, row := range rows {
+		total.TimerWait += row.TimerWait
+		total.LockTime += row.LockTime
+		total.RowsExamined += row.RowsExamined
+		total.RowsSent += row.RowsSent
+	}
+
+	return total
+}
+
+// Available returns true if the consumers needed to see the running
+// statements are enabled
+func Available(db model.QueryExecutor) bool {
+	var count int
+
+	if err := db.QueryRow(selectEnabledConsumersSQL).Scan(&count); err != nil {
+		log.Printf("runningstatements.Available: %v", err)
+		return false
+	}
+
+	log.Printf("runningstatements.Available: %d of 3 consumers enabled", count)
+	return count == 3
+}
+
+// Collect returns the statements currently being run by other
+// connections, or no rows if the consumers needed are not enabled or
+// the tables can not be read.
+func Collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter) (Rows, error) {
+	var t Rows
+
+	log.Printf("runningstatements.Collect(?,%q)\n", databaseFilter)
+
+	if !Available(db) {
+		return t, nil
+	}
+
+	query := `SELECT t.THREAD_ID, s.EVENT_ID, t.PROCESSLIST_ID, t.PROCESSLIST_USER, t.PROCESSLIST_HOST, s.CURRENT_SCHEMA, s.EVENT_NAME, s.SQL_TEXT, s.TIMER_WAIT, s.LOCK_TIME, s.ROWS_EXAMINED, s.ROWS_SENT FROM performance_schema.threads t JOIN performance_schema.events_statements_current s ON s.THREAD_ID = t.THREAD_ID WHERE t.TYPE = 'FOREGROUND' AND s.END_EVENT_ID IS NULL AND t.PROCESSLIST_ID <> CONNECTION_ID()`
+	args := []interface{}{}
+
+	// Apply the filter if provided and seems good.
+	if len(databaseFilter.Args()) > 0 {
+		query = fmt.Sprintf("%s%s", query, databaseFilter.ExtraSQLFor("s.CURRENT_SCHEMA"))
+
+		for _, v := range databaseFilter.Args() {
+			args = append(args, v)
+		}
+		log.Printf("apply databaseFilter: sql: %q, args: %+v\n", query, args)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		if model.IsExpectedError(err) {
+			log.Printf("runningstatements.Collect: ignoring expected error: %v", err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("runningstatements.Collect: %w", err)
+	}
+
+	t = common.Collect(rows, func() (Row, error) {
+		var (
+			processlistID      sql.NullInt64
+			user, host, schema sql.NullString
+			text               sql.NullString
+			timerWait          sql.NullInt64
+			r                  Row
+		)
+		if err := rows.Scan(
+			&r.ThreadID,
+			&r.EventID,
+			&processlistID,
+			&user,
+			&host,
+			&schema,
+			&r.EventName,
+			&text,
+			&timerWait,
+			&r.LockTime,
+			&r.RowsExamined,
+			&r.RowsSent); err != nil {
+			return r, err
+		}
+		if processlistID.Valid && processlistID.Int64 >= 0 {
+			r.ProcesslistID = uint64(processlistID.Int64)
+		}
+		if timerWait.Valid && timerWait.Int64 >= 0 {
+			r.TimerWait = uint64(timerWait.Int64)
+		}
+		r.User = utils.Anonymise("user", user.String)
+		r.Host = host.String
+		if schema.Valid {
+			r.Schema = utils.Anonymise("schema", schema.String)
+		}
+		// the statement text contains the unquoted names of tables
+		// and columns so can not be anonymised
+		if !anonymiser.Enabled() {
+			r.Text = text.String
+		}
+		return r, nil
+	})
+
+	return t, nil
+}
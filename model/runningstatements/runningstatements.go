@@ -0,0 +1,45 @@
+package runningstatements
+
+import (
+	"github.com/sjmudd/ps-top/model"
+)
+
+// RunningStatements holds the statements currently running
+type RunningStatements struct {
+	*model.BaseCollector[Row, Rows]
+}
+
+// NewRunningStatements creates a new RunningStatements instance.
+func NewRunningStatements(cfg model.Config, db model.QueryExecutor) *RunningStatements {
+	process := func(last, _ Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		tot := totals(results)
+		return results, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &RunningStatements{BaseCollector: bc}
+}
+
+// Collect collects the statements running now. There is nothing to
+// compare with previous collections so the values are always absolute.
+func (rs *RunningStatements) Collect() {
+	bc := rs.BaseCollector
+	fetch := func() (Rows, error) {
+		return Collect(bc.DB(), bc.Config().DatabaseFilter())
+	}
+	wantRefresh := func() bool {
+		return true
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats returns false as the statements are only seen while running
+func (rs RunningStatements) HaveRelativeStats() bool {
+	return false
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (rs RunningStatements) WantRelativeStats() bool {
+	return rs.Config().WantRelativeStats()
+}
@@ -0,0 +1,72 @@
+// Package stageprogress contains the routines for managing the stages
+// running now from performance_schema.events_stages_current.
+package stageprogress
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+// MySQL 8.4 (columns used)
+CREATE TABLE `events_stages_current` (
+  `THREAD_ID` bigint unsigned NOT NULL,
+  `EVENT_ID` bigint unsigned NOT NULL,
+  `END_EVENT_ID` bigint unsigned DEFAULT NULL,
+  `EVENT_NAME` varchar(128) NOT NULL,
+  `TIMER_WAIT` bigint unsigned DEFAULT NULL,
+  `WORK_COMPLETED` bigint unsigned DEFAULT NULL,
+  `WORK_ESTIMATED` bigint unsigned DEFAULT NULL,
+  `NESTING_EVENT_ID` bigint unsigned DEFAULT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+The work done is only estimated by some stages, such as those of
+stage/innodb/alter%, where it is for the whole statement.
+*/
+
+// Row contains a stage running now
+type Row struct {
+	ThreadID      uint64
+	ProcesslistID uint64
+	User          string
+	Host          string
+	Schema        string
+	EventName     string
+	TimerWait     uint64 // time the statement has taken so far in picoseconds
+	WorkCompleted uint64
+	WorkEstimated uint64
+	Text          string // the statement running
+}
+
+// Name returns the stage name without the stage/ prefix
+func (row Row) Name() string {
+	return strings.TrimPrefix(row.EventName, "stage/")
+}
+
+// Key returns the thread running the stage, which unlike its name is unique
+func (row Row) Key() string {
+	return fmt.Sprintf("thread %d", row.ThreadID)
+}
+
+// Progress returns the fraction of the estimated work completed, 0 if not estimated
+func (row Row) Progress() float64 {
+	if row.WorkEstimated == 0 {
+		return 0
+	}
+	return min(float64(row.WorkCompleted)/float64(row.WorkEstimated), 1)
+}
+
+// Remaining returns the estimated time in picoseconds to complete the
+// work assuming it continues at the same rate, 0 if it can not be estimated
+func (row Row) Remaining() uint64 {
+	if row.WorkCompleted == 0 || row.WorkCompleted >= row.WorkEstimated {
+		return 0
+	}
+	return uint64(float64(row.TimerWait) * float64(row.WorkEstimated-row.WorkCompleted) / float64(row.WorkCompleted))
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.EventName != ""
+}
@@ -0,0 +1,27 @@
+package stageprogress
+
+import (
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	tests := []struct {
+		row       Row
+		progress  float64
+		remaining uint64
+	}{
+		{Row{TimerWait: 1000}, 0, 0},
+		{Row{TimerWait: 1000, WorkEstimated: 100}, 0, 0},
+		{Row{TimerWait: 1000, WorkCompleted: 25, WorkEstimated: 100}, 0.25, 3000},
+		{Row{TimerWait: 1000, WorkCompleted: 100, WorkEstimated: 100}, 1, 0},
+		{Row{TimerWait: 1000, WorkCompleted: 120, WorkEstimated: 100}, 1, 0}, // the estimate was too low
+	}
+	for _, test := range tests {
+		if got := test.row.Progress(); got != test.progress {
+			t.Errorf("%+v.Progress() = %v, want %v", test.row, got, test.progress)
+		}
+		if got := test.row.Remaining(); got != test.remaining {
+			t.Errorf("%+v.Remaining() = %v, want %v", test.row, got, test.remaining)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package stageprogress
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/anonymiser"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+	"github.com/sjmudd/ps-top/model/filter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// Rows contains a set of rows
+type Rows []Row
+
+func totals(_ Rows) Row {
+	return Row{EventName: "Totals"}
+}
+
+// collect returns the stages being run now by other connections. The
+// time taken is that of the statement as the work estimated is for the
+// whole statement.
+func collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter) Rows {
+	log.Printf("collect(?,%q)\n", databaseFilter)
+
+	query := `
+SELECT	t.THREAD_ID,
+	COALESCE(t.PROCESSLIST_ID, 0),
+	t.PROCESSLIST_USER,
+	t.PROCESSLIST_HOST,
+	t.PROCESSLIST_DB,
+	s.EVENT_NAME,
+	COALESCE(st.TIMER_WAIT, s.TIMER_WAIT),
+	COALESCE(s.WORK_COMPLETED, 0),
+	COALESCE(s.WORK_ESTIMATED, 0),
+	st.SQL_TEXT
+FROM	performance_schema.events_stages_current s
+JOIN	performance_schema.threads t ON t.THREAD_ID = s.THREAD_ID
+LEFT JOIN performance_schema.events_statements_current st ON st.THREAD_ID = s.THREAD_ID AND st.EVENT_ID = s.NESTING_EVENT_ID
+WHERE	s.END_EVENT_ID IS NULL
+AND	(t.PROCESSLIST_ID IS NULL OR t.PROCESSLIST_ID <> CONNECTION_ID())`
+	args := []interface{}{}
+
+	// Apply the filter if provided and seems good.
+	if len(databaseFilter.Args()) > 0 {
+		query = fmt.Sprintf("%s%s", query, databaseFilter.ExtraSQLFor("t.PROCESSLIST_DB"))
+
+		for _, v := range databaseFilter.Args() {
+			args = append(args, v)
+		}
+		log.Printf("apply databaseFilter: sql: %q, args: %+v\n", query, args)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		// the WORK_* columns are missing before MySQL 5.7 and the
+		// tables may not be readable by the user
+		if model.IsExpectedError(err) {
+			log.Printf("stageprogress.collect: ignoring expected error: %v", err)
+			return nil
+		}
+		log.Fatal(err)
+	}
+
+	return common.Collect(rows, func() (Row, error) {
+		var (
+			user, host, schema sql.NullString
+			timerWait          sql.NullInt64
+			text               sql.NullString
+			r                  Row
+		)
+		if err := rows.Scan(
+			&r.ThreadID,
+			&r.ProcesslistID,
+			&user,
+			&host,
+			&schema,
+			&r.EventName,
+			&timerWait,
+			&r.WorkCompleted,
+			&r.WorkEstimated,
+			&text); err != nil {
+			return r, err
+		}
+		if timerWait.Valid && timerWait.Int64 >= 0 {
+			r.TimerWait = uint64(timerWait.Int64)
+		}
+		r.User = utils.Anonymise("user", user.String)
+		r.Host = host.String
+		if schema.Valid {
+			r.Schema = utils.Anonymise("schema", schema.String)
+		}
+		// the statement text contains the unquoted names of tables
+		// and columns so can not be anonymised
+		if !anonymiser.Enabled() {
+			r.Text = text.String
+		}
+		return r, nil
+	})
+}
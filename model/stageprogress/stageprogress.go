@@ -0,0 +1,45 @@
+package stageprogress
+
+import (
+	"github.com/sjmudd/ps-top/model"
+)
+
+// StageProgress holds the stages running now
+type StageProgress struct {
+	*model.BaseCollector[Row, Rows]
+}
+
+// NewStageProgress creates a new StageProgress instance.
+func NewStageProgress(cfg model.Config, db model.QueryExecutor) *StageProgress {
+	process := func(last, _ Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		tot := totals(results)
+		return results, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &StageProgress{BaseCollector: bc}
+}
+
+// Collect collects the stages running now. There is nothing to
+// compare with previous collections so the values are always absolute.
+func (sp *StageProgress) Collect() {
+	bc := sp.BaseCollector
+	fetch := func() (Rows, error) {
+		return collect(bc.DB(), bc.Config().DatabaseFilter()), nil
+	}
+	wantRefresh := func() bool {
+		return true
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats returns false as the stages are only seen while running
+func (sp StageProgress) HaveRelativeStats() bool {
+	return false
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (sp StageProgress) WantRelativeStats() bool {
+	return sp.Config().WantRelativeStats()
+}
@@ -0,0 +1,80 @@
+// Package statementdigest contains the routines for managing
+// performance_schema.events_statements_summary_by_digest.
+package statementdigest
+
+/*
+// MySQL 8.4 (columns used)
+CREATE TABLE `events_statements_summary_by_digest` (
+  `SCHEMA_NAME` varchar(64) DEFAULT NULL,
+  `DIGEST` varchar(64) DEFAULT NULL,
+  `DIGEST_TEXT` longtext,
+  `COUNT_STAR` bigint unsigned NOT NULL,
+  `SUM_TIMER_WAIT` bigint unsigned NOT NULL,
+  `MAX_TIMER_WAIT` bigint unsigned NOT NULL,
+  `SUM_ROWS_SENT` bigint unsigned NOT NULL,
+  `SUM_ROWS_EXAMINED` bigint unsigned NOT NULL,
+  `SUM_CREATED_TMP_DISK_TABLES` bigint unsigned NOT NULL,
+  `SUM_CREATED_TMP_TABLES` bigint unsigned NOT NULL,
+  `SUM_NO_INDEX_USED` bigint unsigned NOT NULL,
+  `SUM_NO_GOOD_INDEX_USED` bigint unsigned NOT NULL,
+  ...
+  UNIQUE KEY `SCHEMA_NAME` (`SCHEMA_NAME`,`DIGEST`)
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb3
+*/
+
+// otherStatements names the row holding statements which did not fit
+// in the table (DIGEST and DIGEST_TEXT are NULL)
+const otherStatements = "<other statements>"
+
+// Row contains a row from events_statements_summary_by_digest
+type Row struct {
+	Schema string // default database of the statements, empty if none
+	Digest string // digest of the normalised statement
+	Text   string // normalised statement (identifiers are anonymised if requested)
+
+	SumTimerWait            uint64
+	MaxTimerWait            uint64 // highest latency seen, this is not a counter
+	CountStar               uint64
+	SumRowsExamined         uint64
+	SumRowsSent             uint64
+	SumCreatedTmpTables     uint64
+	SumCreatedTmpDiskTables uint64
+	SumNoIndexUsed          uint64
+	SumNoGoodIndexUsed      uint64
+}
+
+// key uniquely identifies the statements of a row
+func (row Row) key() string {
+	return row.Schema + "\x00" + row.Digest
+}
+
+// Name returns the statement text prefixed by the schema, if there is one
+func (row Row) Name() string {
+	if row.Schema == "" {
+		return row.Text
+	}
+	return row.Schema + ": " + row.Text
+}
+
+// subtract the countable values in one row from another.
+// MaxTimerWait is a high water mark so is kept. If the digest has been
+// removed from the table and added again since other was collected
+// nothing is subtracted.
+func (row *Row) subtract(other Row) {
+	if row.SumTimerWait < other.SumTimerWait || row.CountStar < other.CountStar {
+		return
+	}
+	row.SumTimerWait -= other.SumTimerWait
+	row.CountStar -= other.CountStar
+	row.SumRowsExamined -= other.SumRowsExamined
+	row.SumRowsSent -= other.SumRowsSent
+	row.SumCreatedTmpTables -= other.SumCreatedTmpTables
+	row.SumCreatedTmpDiskTables -= other.SumCreatedTmpDiskTables
+	row.SumNoIndexUsed -= other.SumNoIndexUsed
+	row.SumNoGoodIndexUsed -= other.SumNoGoodIndexUsed
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.CountStar > 0
+}
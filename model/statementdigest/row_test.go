@@ -0,0 +1,65 @@
+package statementdigest
+
+import (
+	"testing"
+
+	"github.com/sjmudd/anonymiser"
+)
+
+func TestSubtract(t *testing.T) {
+	var tests = []struct {
+		val1     Row
+		val2     Row
+		expected Row
+	}{
+		{
+			Row{"db", "d1", "SELECT ?", 300, 90, 30, 1000, 100, 4, 2, 10, 1},
+			Row{"db", "d1", "SELECT ?", 100, 50, 10, 400, 40, 1, 1, 4, 0},
+			Row{"db", "d1", "SELECT ?", 200, 90, 20, 600, 60, 3, 1, 6, 1},
+		},
+		{
+			// the digest was removed and added again so nothing is subtracted
+			Row{"db", "d1", "SELECT ?", 30, 9, 3, 10, 1, 0, 0, 0, 0},
+			Row{"db", "d1", "SELECT ?", 100, 50, 10, 400, 40, 1, 1, 4, 0},
+			Row{"db", "d1", "SELECT ?", 30, 9, 3, 10, 1, 0, 0, 0, 0},
+		},
+	}
+
+	for _, test := range tests {
+		got := test.val1
+		got.subtract(test.val2)
+		if got != test.expected {
+			t.Errorf("r(%v).subtract(%v) failed: expected: %v, got: %v", test.val1, test.val2, test.expected, got)
+		}
+	}
+}
+
+func TestTotals(t *testing.T) {
+	rows := Rows{
+		{Schema: "db", Digest: "d1", SumTimerWait: 100, MaxTimerWait: 60, CountStar: 2, SumNoIndexUsed: 1},
+		{Schema: "db", Digest: "d2", SumTimerWait: 50, MaxTimerWait: 40, CountStar: 5, SumNoIndexUsed: 2},
+	}
+	expected := Row{Text: "Totals", SumTimerWait: 150, MaxTimerWait: 60, CountStar: 7, SumNoIndexUsed: 3}
+
+	if got := totals(rows); got != expected {
+		t.Errorf("totals(%v) failed: expected: %v, got: %v", rows, expected, got)
+	}
+}
+
+func TestAnonymiseText(t *testing.T) {
+	const text = "SELECT `id` FROM `db` . `t1` WHERE `id` = ?"
+
+	enabled := anonymiser.Enabled()
+	defer anonymiser.Enable(enabled)
+
+	anonymiser.Enable(false)
+	if got := AnonymiseText(text); got != text {
+		t.Errorf("AnonymiseText(%q) with anonymising disabled: got %q", text, got)
+	}
+
+	anonymiser.Enable(true)
+	expected := "SELECT `identifier1` FROM `identifier2` . `identifier3` WHERE `identifier1` = ?"
+	if got := AnonymiseText(text); got != expected {
+		t.Errorf("AnonymiseText(%q) failed: expected: %q, got: %q", text, expected, got)
+	}
+}
@@ -0,0 +1,110 @@
+package statementdigest
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sjmudd/anonymiser"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+	"github.com/sjmudd/ps-top/model/filter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// reIdentifier matches the quoted identifiers of a normalised statement
+var reIdentifier = regexp.MustCompile("`[^`]+`")
+
+// Rows contains a set of rows
+type Rows []Row
+
+func totals(rows Rows) Row {
+	total := Row{Text: "Totals"}
+
+	for _, row := range rows {
+		total.SumTimerWait += row.SumTimerWait
+		total.MaxTimerWait = max(total.MaxTimerWait, row.MaxTimerWait)
+		total.CountStar += row.CountStar
+		total.SumRowsExamined += row.SumRowsExamined
+		total.SumRowsSent += row.SumRowsSent
+		total.SumCreatedTmpTables += row.SumCreatedTmpTables
+		total.SumCreatedTmpDiskTables += row.SumCreatedTmpDiskTables
+		total.SumNoIndexUsed += row.SumNoIndexUsed
+		total.SumNoGoodIndexUsed += row.SumNoGoodIndexUsed
+	}
+
+	return total
+}
+
+// AnonymiseText anonymises the identifiers of a normalised statement if
+// anonymising is enabled. The statement's values have already been
+// replaced by placeholders.
+func AnonymiseText(text string) string {
+	if !anonymiser.Enabled() {
+		return text
+	}
+	return reIdentifier.ReplaceAllStringFunc(text, func(identifier string) string {
+		return "`" + utils.Anonymise("identifier", strings.Trim(identifier, "`")) + "`"
+	})
+}
+
+func collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter) Rows {
+	var t Rows
+
+	log.Printf("collect(?,%q)\n", databaseFilter)
+
+	query := `SELECT SCHEMA_NAME, DIGEST, DIGEST_TEXT, COUNT_STAR, SUM_TIMER_WAIT, MAX_TIMER_WAIT, SUM_ROWS_EXAMINED, SUM_ROWS_SENT, SUM_CREATED_TMP_TABLES, SUM_CREATED_TMP_DISK_TABLES, SUM_NO_INDEX_USED, SUM_NO_GOOD_INDEX_USED FROM events_statements_summary_by_digest WHERE SUM_TIMER_WAIT > 0`
+	args := []interface{}{}
+
+	// Apply the filter if provided and seems good.
+	if len(databaseFilter.Args()) > 0 {
+		query = fmt.Sprintf("%s%s", query, databaseFilter.ExtraSQLFor("SCHEMA_NAME"))
+
+		for _, v := range databaseFilter.Args() {
+			args = append(args, v)
+		}
+		log.Printf("apply databaseFilter: sql: %q, args: %+v\n", query, args)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		// the table may be missing or not readable by the user
+		if model.IsExpectedError(err) {
+			log.Printf("statementdigest.collect: ignoring expected error: %v", err)
+			return nil
+		}
+		log.Fatal(err)
+	}
+
+	t = common.Collect(rows, func() (Row, error) {
+		var schema, digest, text sql.NullString
+		var r Row
+		if err := rows.Scan(
+			&schema,
+			&digest,
+			&text,
+			&r.CountStar,
+			&r.SumTimerWait,
+			&r.MaxTimerWait,
+			&r.SumRowsExamined,
+			&r.SumRowsSent,
+			&r.SumCreatedTmpTables,
+			&r.SumCreatedTmpDiskTables,
+			&r.SumNoIndexUsed,
+			&r.SumNoGoodIndexUsed); err != nil {
+			return r, err
+		}
+		r.Schema = utils.Anonymise("schema", schema.String)
+		r.Digest = digest.String
+		r.Text = AnonymiseText(text.String)
+		if !digest.Valid {
+			r.Text = otherStatements
+		}
+		return r, nil
+	})
+
+	return t
+}
@@ -0,0 +1,54 @@
+package statementdigest
+
+import (
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+)
+
+// StatementDigest holds the statistics of each normalised statement
+type StatementDigest struct {
+	*model.BaseCollector[Row, Rows]
+}
+
+// NewStatementDigest creates a new StatementDigest instance.
+func NewStatementDigest(cfg model.Config, db model.QueryExecutor) *StatementDigest {
+	process := func(last, first Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		if cfg.WantRelativeStats() {
+			common.SubtractByName(&results, first,
+				func(r Row) string { return r.key() },
+				func(r *Row, o Row) { r.subtract(o) },
+			)
+		}
+		tot := totals(results)
+		return results, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &StatementDigest{BaseCollector: bc}
+}
+
+// Collect collects data from the db, updating initial values
+// if needed, and then subtracting initial values if we want relative
+// values, after which it stores totals.
+func (sd *StatementDigest) Collect() {
+	bc := sd.BaseCollector
+	fetch := func() (Rows, error) {
+		return collect(bc.DB(), bc.Config().DatabaseFilter()), nil
+	}
+	wantRefresh := func() bool {
+		// the table has been truncated if the totals go down
+		return (len(bc.First) == 0 && len(bc.Last) > 0) || totals(bc.First).SumTimerWait > totals(bc.Last).SumTimerWait
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats is true for this object
+func (sd StatementDigest) HaveRelativeStats() bool {
+	return true
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (sd StatementDigest) WantRelativeStats() bool {
+	return sd.Config().WantRelativeStats()
+}
@@ -0,0 +1,115 @@
+// Package statementhistogram contains the routines for managing
+// performance_schema.events_statements_histogram_by_digest and
+// events_statements_histogram_global (MySQL 8.0.19+).
+package statementhistogram
+
+import (
+	"math"
+)
+
+/*
+// MySQL 8.4
+CREATE TABLE `events_statements_histogram_by_digest` (
+  `SCHEMA_NAME` varchar(64) DEFAULT NULL,
+  `DIGEST` varchar(64) DEFAULT NULL,
+  `BUCKET_NUMBER` int unsigned NOT NULL,
+  `BUCKET_TIMER_LOW` bigint unsigned NOT NULL,
+  `BUCKET_TIMER_HIGH` bigint unsigned NOT NULL,
+  `COUNT_BUCKET` bigint unsigned NOT NULL,
+  `COUNT_BUCKET_AND_LOWER` bigint unsigned NOT NULL,
+  `BUCKET_QUANTILE` double(7,6) NOT NULL,
+  UNIQUE KEY `SCHEMA_NAME` (`SCHEMA_NAME`,`DIGEST`,`BUCKET_NUMBER`)
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb3
+
+events_statements_histogram_global is the same without SCHEMA_NAME and
+DIGEST. The statement text comes from events_statements_summary_by_digest.
+*/
+
+const (
+	// allStatements names the row holding the histogram of all statements
+	allStatements = "<all statements>"
+	// otherStatements names the row holding statements which did not fit
+	// in the table (DIGEST is NULL)
+	otherStatements = "<other statements>"
+)
+
+// Bucket holds the number of statements whose latency was in the range of a bucket
+type Bucket struct {
+	Number    uint64 // BUCKET_NUMBER, the buckets of all histograms have the same ranges
+	TimerHigh uint64 // upper bound of the latency of the bucket in picoseconds
+	Count     uint64
+}
+
+// Row contains the latency histogram of a statement digest
+type Row struct {
+	Schema string // default database of the statements, empty if none
+	Digest string // digest of the normalised statement
+	Text   string // normalised statement (identifiers are anonymised if requested)
+
+	CountStar uint64   // the number of statements in all the buckets
+	Buckets   []Bucket // buckets containing statements, ordered by number
+}
+
+// key uniquely identifies the statements of a row
+func (row Row) key() string {
+	if row.Digest == "" {
+		return row.Schema + "\x00" + row.Text // all or other statements
+	}
+	return row.Schema + "\x00" + row.Digest
+}
+
+// Name returns the statement text prefixed by the schema, if there is one
+func (row Row) Name() string {
+	if row.Schema == "" {
+		return row.Text
+	}
+	return row.Schema + ": " + row.Text
+}
+
+// Percentile returns the upper bound of the bucket holding the statement
+// at the given percentile (0 to 100) of the latency, or 0 if there are
+// no statements.
+func (row Row) Percentile(percentile float64) uint64 {
+	// the position of the statement wanted, counting from 1
+	wanted := max(uint64(math.Ceil(float64(row.CountStar)*percentile/100)), 1)
+
+	var seen uint64
+	for _, bucket := range row.Buckets {
+		seen += bucket.Count
+		if seen >= wanted {
+			return bucket.TimerHigh
+		}
+	}
+	return 0
+}
+
+// subtract the bucket counts in one row from another. A new slice of
+// buckets is created as the buckets may be shared with the collected
+// data. If the digest has been removed from the table and added again
+// since other was collected nothing is subtracted.
+func (row *Row) subtract(other Row) {
+	if row.CountStar < other.CountStar {
+		return
+	}
+
+	initial := make(map[uint64]uint64, len(other.Buckets))
+	for _, bucket := range other.Buckets {
+		initial[bucket.Number] = bucket.Count
+	}
+
+	buckets := make([]Bucket, 0, len(row.Buckets))
+	for _, bucket := range row.Buckets {
+		bucket.Count -= min(bucket.Count, initial[bucket.Number])
+		if bucket.Count > 0 {
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	row.CountStar -= other.CountStar
+	row.Buckets = buckets
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.CountStar > 0
+}
@@ -0,0 +1,51 @@
+package statementhistogram
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	row := Row{
+		CountStar: 100,
+		Buckets: []Bucket{
+			{Number: 10, TimerHigh: 1000, Count: 50},
+			{Number: 20, TimerHigh: 2000, Count: 45},
+			{Number: 30, TimerHigh: 3000, Count: 4},
+			{Number: 40, TimerHigh: 4000, Count: 1},
+		},
+	}
+	tests := []struct {
+		percentile float64
+		expected   uint64
+	}{
+		{0, 1000},
+		{50, 1000},
+		{51, 2000},
+		{95, 2000},
+		{99, 3000},
+		{100, 4000},
+	}
+	for _, test := range tests {
+		if got := row.Percentile(test.percentile); got != test.expected {
+			t.Errorf("Percentile(%v) = %d, want %d", test.percentile, got, test.expected)
+		}
+	}
+	if got := (Row{}).Percentile(99); got != 0 {
+		t.Errorf("Percentile(99) of an empty row = %d, want 0", got)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	buckets := []Bucket{{Number: 1, TimerHigh: 10, Count: 5}, {Number: 2, TimerHigh: 20, Count: 3}}
+	row := Row{Digest: "d1", CountStar: 8, Buckets: buckets}
+	row.subtract(Row{Digest: "d1", CountStar: 5, Buckets: []Bucket{{Number: 1, TimerHigh: 10, Count: 5}}})
+
+	expected := Row{Digest: "d1", CountStar: 3, Buckets: []Bucket{{Number: 2, TimerHigh: 20, Count: 3}}}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("subtract() failed: expected: %+v, got: %+v", expected, row)
+	}
+	if buckets[0].Count != 5 {
+		t.Errorf("subtract() modified the buckets of the collected row: %+v", buckets)
+	}
+}
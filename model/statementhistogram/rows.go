@@ -0,0 +1,148 @@
+package statementhistogram
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+	"github.com/sjmudd/ps-top/model/filter"
+	"github.com/sjmudd/ps-top/model/statementdigest"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+const (
+	globalSQL = `
+SELECT	NULL,
+	NULL,
+	NULL,
+	BUCKET_NUMBER,
+	BUCKET_TIMER_HIGH,
+	COUNT_BUCKET
+FROM	performance_schema.events_statements_histogram_global
+WHERE	COUNT_BUCKET > 0`
+
+	byDigestSQL = `
+SELECT	h.SCHEMA_NAME,
+	h.DIGEST,
+	d.DIGEST_TEXT,
+	h.BUCKET_NUMBER,
+	h.BUCKET_TIMER_HIGH,
+	h.COUNT_BUCKET
+FROM	performance_schema.events_statements_histogram_by_digest h
+LEFT JOIN performance_schema.events_statements_summary_by_digest d ON d.SCHEMA_NAME <=> h.SCHEMA_NAME AND d.DIGEST <=> h.DIGEST
+WHERE	h.COUNT_BUCKET > 0`
+)
+
+// Rows contains a set of rows
+type Rows []Row
+
+// global returns the histogram of all statements, named Totals, and the
+// histograms of each digest
+func (rows Rows) global() (Row, Rows) {
+	total := Row{Text: "Totals"}
+	digests := make(Rows, 0, len(rows))
+
+	for _, row := range rows {
+		if row.Digest == "" && row.Text == allStatements {
+			total.CountStar = row.CountStar
+			total.Buckets = row.Buckets
+			continue
+		}
+		digests = append(digests, row)
+	}
+
+	return total, digests
+}
+
+// bucketRow holds one row of a histogram table
+type bucketRow struct {
+	schema, digest, text sql.NullString
+	bucket               Bucket
+}
+
+// collect returns the histogram of all statements followed by those of each digest
+func collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter) Rows {
+	log.Printf("collect(?,%q)\n", databaseFilter)
+
+	global, ok := collectBuckets(db, globalSQL)
+	if !ok {
+		return nil
+	}
+
+	query := byDigestSQL
+	args := []interface{}{}
+
+	// Apply the filter if provided and seems good.
+	if len(databaseFilter.Args()) > 0 {
+		query = fmt.Sprintf("%s%s", query, databaseFilter.ExtraSQLFor("h.SCHEMA_NAME"))
+
+		for _, v := range databaseFilter.Args() {
+			args = append(args, v)
+		}
+		log.Printf("apply databaseFilter: sql: %q, args: %+v\n", query, args)
+	}
+	query += " ORDER BY h.SCHEMA_NAME, h.DIGEST, h.BUCKET_NUMBER"
+
+	byDigest, _ := collectBuckets(db, query, args...)
+
+	all := Row{Text: allStatements}
+	for _, b := range global {
+		all.CountStar += b.bucket.Count
+		all.Buckets = append(all.Buckets, b.bucket)
+	}
+
+	return append(Rows{all}, histograms(byDigest)...)
+}
+
+// collectBuckets returns the buckets returned by the query, and false if
+// the server is too old to have the histogram tables
+func collectBuckets(db model.QueryExecutor, query string, args ...interface{}) ([]bucketRow, bool) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		// the tables are missing before MySQL 8.0.19 or may not be
+		// readable by the user
+		if model.IsExpectedError(err) {
+			log.Printf("statementhistogram.collectBuckets: ignoring expected error: %v", err)
+			return nil, false
+		}
+		log.Fatal(err)
+	}
+
+	return common.Collect(rows, func() (bucketRow, error) {
+		var b bucketRow
+		err := rows.Scan(
+			&b.schema,
+			&b.digest,
+			&b.text,
+			&b.bucket.Number,
+			&b.bucket.TimerHigh,
+			&b.bucket.Count)
+		return b, err
+	}), true
+}
+
+// histograms groups the buckets of each digest, which must be adjacent, into rows
+func histograms(buckets []bucketRow) Rows {
+	var t Rows
+
+	for i, b := range buckets {
+		if i == 0 || b.schema != buckets[i-1].schema || b.digest != buckets[i-1].digest {
+			r := Row{
+				Schema: utils.Anonymise("schema", b.schema.String),
+				Digest: b.digest.String,
+				Text:   statementdigest.AnonymiseText(b.text.String),
+			}
+			if !b.digest.Valid {
+				r.Text = otherStatements
+			}
+			t = append(t, r)
+		}
+		r := &t[len(t)-1]
+		r.CountStar += b.bucket.Count
+		r.Buckets = append(r.Buckets, b.bucket)
+	}
+
+	return t
+}
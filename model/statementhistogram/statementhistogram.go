@@ -0,0 +1,57 @@
+package statementhistogram
+
+import (
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+)
+
+// StatementHistogram holds the latency histograms of the statement digests
+type StatementHistogram struct {
+	*model.BaseCollector[Row, Rows]
+}
+
+// NewStatementHistogram creates a new StatementHistogram instance.
+// The totals are the histogram of all statements.
+func NewStatementHistogram(cfg model.Config, db model.QueryExecutor) *StatementHistogram {
+	process := func(last, first Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		if cfg.WantRelativeStats() {
+			common.SubtractByName(&results, first,
+				func(r Row) string { return r.key() },
+				func(r *Row, o Row) { r.subtract(o) },
+			)
+		}
+		tot, digests := results.global()
+		return digests, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &StatementHistogram{BaseCollector: bc}
+}
+
+// Collect collects data from the db, updating initial values
+// if needed, and then subtracting initial values if we want relative
+// values, after which it stores totals.
+func (sh *StatementHistogram) Collect() {
+	bc := sh.BaseCollector
+	fetch := func() (Rows, error) {
+		return collect(bc.DB(), bc.Config().DatabaseFilter()), nil
+	}
+	wantRefresh := func() bool {
+		// the tables have been truncated if the number of statements goes down
+		first, _ := bc.First.global()
+		last, _ := bc.Last.global()
+		return (len(bc.First) == 0 && len(bc.Last) > 0) || first.CountStar > last.CountStar
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats is true for this object
+func (sh StatementHistogram) HaveRelativeStats() bool {
+	return true
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (sh StatementHistogram) WantRelativeStats() bool {
+	return sh.Config().WantRelativeStats()
+}
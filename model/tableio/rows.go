@@ -3,6 +3,7 @@
 package tableio
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/sjmudd/ps-top/log"
@@ -12,6 +13,28 @@ import (
 	"github.com/sjmudd/ps-top/utils"
 )
 
+// collection selects what is collected
+type collection int
+
+const (
+	byTable       collection = iota // table_io_waits_summary_by_table
+	byIndex                         // table_io_waits_summary_by_index_usage
+	unusedIndexes                   // secondary indexes not read since the server started
+)
+
+// fullScan names the index of the row of table_io_waits_summary_by_index_usage
+// whose INDEX_NAME is NULL. It counts the rows read by table scans (and inserts).
+const fullScan = "<full scan>"
+
+const tableIOColumns = `COUNT_STAR, SUM_TIMER_WAIT, COUNT_READ, SUM_TIMER_READ, COUNT_WRITE, SUM_TIMER_WRITE, COUNT_FETCH, SUM_TIMER_FETCH, COUNT_INSERT, SUM_TIMER_INSERT, COUNT_UPDATE, SUM_TIMER_UPDATE, COUNT_DELETE, SUM_TIMER_DELETE`
+
+// collectSQL holds the query used for each collection
+var collectSQL = map[collection]string{
+	byTable:       `SELECT OBJECT_SCHEMA, OBJECT_NAME, ` + tableIOColumns + ` FROM table_io_waits_summary_by_table WHERE SUM_TIMER_WAIT > 0`,
+	byIndex:       `SELECT OBJECT_SCHEMA, OBJECT_NAME, INDEX_NAME, ` + tableIOColumns + ` FROM table_io_waits_summary_by_index_usage WHERE SUM_TIMER_WAIT > 0`,
+	unusedIndexes: `SELECT OBJECT_SCHEMA, OBJECT_NAME, INDEX_NAME, ` + tableIOColumns + ` FROM table_io_waits_summary_by_index_usage WHERE INDEX_NAME IS NOT NULL AND INDEX_NAME <> 'PRIMARY' AND COUNT_READ = 0 AND OBJECT_SCHEMA NOT IN ('mysql', 'performance_schema', 'sys')`,
+}
+
 // Rows contains a set of rows
 type Rows []Row
 
@@ -39,36 +62,53 @@ func totals(rows Rows) Row {
 	return total
 }
 
-func collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter) Rows {
+// indexName returns the qualified name of an index of a table
+func indexName(schema, table string, index sql.NullString) string {
+	name := fullScan
+	if index.Valid {
+		name = utils.Anonymise("index", index.String)
+	}
+	return utils.QualifiedTableName(schema, table) + "." + name
+}
+
+func collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter, c collection) Rows {
 	var t Rows
 
-	log.Printf("collect(?,%q)\n", databaseFilter)
+	log.Printf("collect(?,%q,%v)\n", databaseFilter, c)
 
 	// we collect all information even if it's mainly empty as we may reference it later
-	sql := `SELECT OBJECT_SCHEMA, OBJECT_NAME, COUNT_STAR, SUM_TIMER_WAIT, COUNT_READ, SUM_TIMER_READ, COUNT_WRITE, SUM_TIMER_WRITE, COUNT_FETCH, SUM_TIMER_FETCH, COUNT_INSERT, SUM_TIMER_INSERT, COUNT_UPDATE, SUM_TIMER_UPDATE, COUNT_DELETE, SUM_TIMER_DELETE FROM table_io_waits_summary_by_table WHERE SUM_TIMER_WAIT > 0`
+	query := collectSQL[c]
 	args := []interface{}{}
 
 	// Apply the filter if provided and seems good.
 	if len(databaseFilter.Args()) > 0 {
-		sql = fmt.Sprintf("%s%s", sql, databaseFilter.ExtraSQL())
+		query = fmt.Sprintf("%s%s", query, databaseFilter.ExtraSQL())
 
 		for _, v := range databaseFilter.Args() {
 			args = append(args, v)
 		}
-		log.Printf("apply databaseFilter: sql: %q, args: %+v\n", sql, args)
+		log.Printf("apply databaseFilter: sql: %q, args: %+v\n", query, args)
 	}
 
-	rows, err := db.Query(sql, args...)
+	rows, err := db.Query(query, args...)
 	if err != nil {
+		// the table may not be readable by the user
+		if model.IsExpectedError(err) {
+			log.Printf("tableio.collect: ignoring expected error: %v", err)
+			return nil
+		}
 		log.Fatal(err)
 	}
 
 	t = common.Collect(rows, func() (Row, error) {
 		var schema, table string
+		var index sql.NullString
 		var r Row
-		if err := rows.Scan(
-			&schema,
-			&table,
+		dest := []any{&schema, &table}
+		if c != byTable {
+			dest = append(dest, &index)
+		}
+		if err := rows.Scan(append(dest,
 			&r.CountStar,
 			&r.SumTimerWait,
 			&r.CountRead,
@@ -82,10 +122,13 @@ func collect(db model.QueryExecutor, databaseFilter *filter.DatabaseFilter) Rows
 			&r.CountUpdate,
 			&r.SumTimerUpdate,
 			&r.CountDelete,
-			&r.SumTimerDelete); err != nil {
+			&r.SumTimerDelete)...); err != nil {
 			return r, err
 		}
 		r.Name = utils.QualifiedTableName(schema, table)
+		if c != byTable {
+			r.Name = indexName(schema, table, index)
+		}
 
 		// we collect all information even if it's mainly empty as we may reference it later
 		// Reference schema to differ slightly from other collect implementations
@@ -0,0 +1,28 @@
+package tableio
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/sjmudd/anonymiser"
+)
+
+func TestIndexName(t *testing.T) {
+	var tests = []struct {
+		index    sql.NullString
+		expected string
+	}{
+		{sql.NullString{String: "PRIMARY", Valid: true}, "db.t1.PRIMARY"},
+		{sql.NullString{}, "db.t1." + fullScan},
+	}
+
+	enabled := anonymiser.Enabled()
+	defer anonymiser.Enable(enabled)
+	anonymiser.Enable(false)
+
+	for _, test := range tests {
+		if got := indexName("db", "t1", test.index); got != test.expected {
+			t.Errorf("indexName(%q, %q, %v) failed: expected: %q, got: %q", "db", "t1", test.index, test.expected, got)
+		}
+	}
+}
@@ -6,18 +6,38 @@ import (
 	"github.com/sjmudd/ps-top/model/common"
 )
 
-// TableIo contains performance_schema.table_io_waits_summary_by_table data
+// TableIo contains performance_schema.table_io_waits_summary_by_table data,
+// or the same data for each index from table_io_waits_summary_by_index_usage
 type TableIo struct {
 	*model.BaseCollector[Row, Rows]
 	wantLatency bool
+	collection  collection
 }
 
 // NewTableIo creates a new TableIo instance.
 func NewTableIo(cfg model.Config, db model.QueryExecutor) *TableIo {
+	return newTableIo(cfg, db, byTable)
+}
+
+// NewIndexUsage creates a new TableIo instance collecting each index,
+// including the table scans of each table.
+func NewIndexUsage(cfg model.Config, db model.QueryExecutor) *TableIo {
+	return newTableIo(cfg, db, byIndex)
+}
+
+// NewUnusedIndexes creates a new TableIo instance collecting the secondary
+// indexes which have not been read since the server started. These may be
+// candidates for dropping. As this depends on the absolute values there
+// are no relative statistics.
+func NewUnusedIndexes(cfg model.Config, db model.QueryExecutor) *TableIo {
+	return newTableIo(cfg, db, unusedIndexes)
+}
+
+func newTableIo(cfg model.Config, db model.QueryExecutor, c collection) *TableIo {
 	process := func(last, first Rows) (Rows, Row) {
 		results := make(Rows, len(last))
 		copy(results, last)
-		if cfg.WantRelativeStats() {
+		if cfg.WantRelativeStats() && c != unusedIndexes {
 			common.SubtractByName(&results, first,
 				func(r Row) string { return r.Name },
 				func(r *Row, o Row) { r.subtract(o) },
@@ -27,7 +47,7 @@ func NewTableIo(cfg model.Config, db model.QueryExecutor) *TableIo {
 		return results, tot
 	}
 	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
-	return &TableIo{BaseCollector: bc, wantLatency: false}
+	return &TableIo{BaseCollector: bc, wantLatency: false, collection: c}
 }
 
 // Collect collects data from the db, updating initial values
@@ -36,9 +56,12 @@ func NewTableIo(cfg model.Config, db model.QueryExecutor) *TableIo {
 func (tiol *TableIo) Collect() {
 	bc := tiol.BaseCollector
 	fetch := func() (Rows, error) {
-		return collect(bc.DB(), bc.Config().DatabaseFilter()), nil
+		return collect(bc.DB(), bc.Config().DatabaseFilter(), tiol.collection), nil
 	}
 	wantRefresh := func() bool {
+		if tiol.collection == unusedIndexes {
+			return true
+		}
 		return (len(bc.First) == 0 && len(bc.Last) > 0) || totals(bc.First).SumTimerWait > totals(bc.Last).SumTimerWait
 	}
 	bc.Collect(fetch, wantRefresh)
@@ -49,9 +72,9 @@ func (tiol *TableIo) WantsLatency() bool {
 	return tiol.wantLatency
 }
 
-// HaveRelativeStats is true for this object
+// HaveRelativeStats is true for this object unless showing unused indexes
 func (tiol TableIo) HaveRelativeStats() bool {
-	return true
+	return tiol.collection != unusedIndexes
 }
 
 // WantRelativeStats returns whether relative stats are desired based on config
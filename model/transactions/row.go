@@ -0,0 +1,64 @@
+// Package transactions contains the routines for managing the open
+// transactions seen in performance_schema.events_transactions_current
+// or information_schema.INNODB_TRX.
+package transactions
+
+import (
+	"fmt"
+)
+
+/*
+// MySQL 8.4 (columns used)
+CREATE TABLE `events_transactions_current` (
+  `THREAD_ID` bigint unsigned NOT NULL,
+  `STATE` enum('ACTIVE','COMMITTED','ROLLED BACK') DEFAULT NULL,
+  `TIMER_WAIT` bigint unsigned DEFAULT NULL,
+  `ISOLATION_LEVEL` varchar(64) DEFAULT NULL,
+  ...
+) ENGINE=PERFORMANCE_SCHEMA DEFAULT CHARSET=utf8mb4
+
+CREATE TEMPORARY TABLE `INNODB_TRX` (
+  `trx_state` varchar(13) NOT NULL DEFAULT '',
+  `trx_started` datetime NOT NULL DEFAULT '0000-00-00 00:00:00',
+  `trx_mysql_thread_id` bigint unsigned NOT NULL DEFAULT '0',
+  `trx_query` varchar(1024) DEFAULT NULL,
+  `trx_isolation_level` varchar(16) NOT NULL DEFAULT '',
+  `trx_rows_locked` bigint unsigned NOT NULL DEFAULT '0',
+  `trx_rows_modified` bigint unsigned NOT NULL DEFAULT '0',
+  ...
+) ENGINE=MEMORY DEFAULT CHARSET=utf8mb3
+*/
+
+// Row contains an open transaction
+type Row struct {
+	ThreadID       uint64
+	ProcesslistID  uint64
+	User           string
+	Host           string
+	Command        string // processlist command, Sleep if the transaction is idle
+	State          string
+	IsolationLevel string
+	Age            uint64 // time since the transaction started in picoseconds
+	RowsModified   uint64
+	RowsLocked     uint64
+	LastStatement  string // statement running, or the last one run if idle
+}
+
+// Key returns the connection of the transaction, which unlike its name is
+// unique
+func (row Row) Key() string {
+	return fmt.Sprintf("connection %d", row.ProcesslistID)
+}
+
+// Name returns the last statement of the transaction, or its state if not known
+func (row Row) Name() string {
+	if row.LastStatement != "" {
+		return row.LastStatement
+	}
+	return row.State
+}
+
+// HasData indicates if there is data in the row (for counting valid rows)
+func (row *Row) HasData() bool {
+	return row != nil && row.State != ""
+}
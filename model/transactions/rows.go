@@ -0,0 +1,155 @@
+package transactions
+
+import (
+	"database/sql"
+
+	"github.com/sjmudd/anonymiser"
+
+	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/common"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+const (
+	// the consumers which must be enabled for events_transactions_current to be populated
+	selectEnabledConsumersSQL = `SELECT COUNT(*) FROM performance_schema.setup_consumers WHERE NAME IN ('global_instrumentation','thread_instrumentation','events_transactions_current') AND ENABLED = 'YES'`
+
+	// eventsTransactionsSQL uses performance_schema, taking the rows
+	// modified and locked from INNODB_TRX. Only the top level statement
+	// is joined as those of stored programs are nested within it.
+	eventsTransactionsSQL = `
+SELECT	t.THREAD_ID,
+	COALESCE(t.PROCESSLIST_ID, 0),
+	t.PROCESSLIST_USER,
+	t.PROCESSLIST_HOST,
+	t.PROCESSLIST_COMMAND,
+	COALESCE(i.trx_state, x.STATE),
+	x.ISOLATION_LEVEL,
+	x.TIMER_WAIT,
+	TIMESTAMPDIFF(SECOND, i.trx_started, NOW()),
+	COALESCE(i.trx_rows_modified, 0),
+	COALESCE(i.trx_rows_locked, 0),
+	s.SQL_TEXT
+FROM	performance_schema.events_transactions_current x
+JOIN	performance_schema.threads t ON t.THREAD_ID = x.THREAD_ID
+LEFT JOIN information_schema.INNODB_TRX i ON i.trx_mysql_thread_id = t.PROCESSLIST_ID
+LEFT JOIN performance_schema.events_statements_current s ON s.THREAD_ID = t.THREAD_ID AND s.NESTING_EVENT_LEVEL = 0
+WHERE	x.STATE = 'ACTIVE'
+AND	t.PROCESSLIST_ID <> CONNECTION_ID()`
+
+	// innodbTransactionsSQL is used if the transaction events are not
+	// collected. Only the statement running now is known.
+	innodbTransactionsSQL = `
+SELECT	COALESCE(t.THREAD_ID, 0),
+	i.trx_mysql_thread_id,
+	t.PROCESSLIST_USER,
+	t.PROCESSLIST_HOST,
+	t.PROCESSLIST_COMMAND,
+	i.trx_state,
+	i.trx_isolation_level,
+	NULL,
+	TIMESTAMPDIFF(SECOND, i.trx_started, NOW()),
+	i.trx_rows_modified,
+	i.trx_rows_locked,
+	i.trx_query
+FROM	information_schema.INNODB_TRX i
+LEFT JOIN performance_schema.threads t ON t.PROCESSLIST_ID = i.trx_mysql_thread_id
+WHERE	i.trx_mysql_thread_id <> CONNECTION_ID()`
+)
+
+// Rows contains a set of rows
+type Rows []Row
+
+func totals(rows Rows) Row {
+	total := Row{State: "Totals"}
+
+	for _, row := range rows {
+		total.RowsModified += row.RowsModified
+		total.RowsLocked += row.RowsLocked
+	}
+
+	return total
+}
+
+// haveTransactionEvents returns true if the consumers needed to see the
+// transactions in events_transactions_current are enabled
+func haveTransactionEvents(db model.QueryExecutor) bool {
+	var count int
+
+	if err := db.QueryRow(selectEnabledConsumersSQL).Scan(&count); err != nil {
+		log.Printf("transactions.haveTransactionEvents: %v", err)
+		return false
+	}
+
+	log.Printf("transactions.haveTransactionEvents: %d of 3 consumers enabled", count)
+	return count == 3
+}
+
+// collect returns the open transactions of other connections
+func collect(db model.QueryExecutor) Rows {
+	log.Printf("collect(?)\n")
+
+	query := innodbTransactionsSQL
+	if haveTransactionEvents(db) {
+		query = eventsTransactionsSQL
+	}
+
+	rows, err := db.Query(query)
+	if err != nil {
+		if model.IsAccessDeniedError(err) {
+			log.Printf("transactions.collect: ignoring error as the tables can not be read: %v", err)
+			return nil
+		}
+		log.Fatal(err)
+	}
+
+	return common.Collect(rows, func() (Row, error) {
+		var (
+			user, host, command sql.NullString
+			state, isolation    sql.NullString
+			timerWait, seconds  sql.NullInt64
+			statement           sql.NullString
+			r                   Row
+		)
+		if err := rows.Scan(
+			&r.ThreadID,
+			&r.ProcesslistID,
+			&user,
+			&host,
+			&command,
+			&state,
+			&isolation,
+			&timerWait,
+			&seconds,
+			&r.RowsModified,
+			&r.RowsLocked,
+			&statement); err != nil {
+			return r, err
+		}
+		r.User = utils.Anonymise("user", user.String)
+		r.Host = host.String
+		r.Command = command.String
+		r.State = state.String
+		r.IsolationLevel = isolation.String
+		r.Age = age(timerWait, seconds)
+		// the statement text contains the unquoted names of tables
+		// and columns so can not be anonymised
+		if !anonymiser.Enabled() {
+			r.LastStatement = statement.String
+		}
+		return r, nil
+	})
+}
+
+// age returns the age of a transaction in picoseconds using the
+// transaction's timer if it is timed, otherwise its start time.
+func age(timerWait, seconds sql.NullInt64) uint64 {
+	switch {
+	case timerWait.Valid && timerWait.Int64 > 0:
+		return uint64(timerWait.Int64)
+	case seconds.Valid && seconds.Int64 > 0:
+		return uint64(seconds.Int64) * utils.PicosecondsPerSecond
+	}
+	return 0
+}
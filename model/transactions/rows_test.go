@@ -0,0 +1,26 @@
+package transactions
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/sjmudd/ps-top/utils"
+)
+
+func TestAge(t *testing.T) {
+	tests := []struct {
+		timerWait sql.NullInt64
+		seconds   sql.NullInt64
+		expected  uint64
+	}{
+		{sql.NullInt64{}, sql.NullInt64{}, 0},
+		{sql.NullInt64{Int64: 1500000, Valid: true}, sql.NullInt64{Int64: 3, Valid: true}, 1500000},
+		{sql.NullInt64{}, sql.NullInt64{Int64: 3, Valid: true}, 3 * utils.PicosecondsPerSecond},
+		{sql.NullInt64{Int64: 0, Valid: true}, sql.NullInt64{Int64: 2, Valid: true}, 2 * utils.PicosecondsPerSecond},
+	}
+	for _, test := range tests {
+		if got := age(test.timerWait, test.seconds); got != test.expected {
+			t.Errorf("age(%v, %v) = %d, want %d", test.timerWait, test.seconds, got, test.expected)
+		}
+	}
+}
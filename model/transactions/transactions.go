@@ -0,0 +1,45 @@
+package transactions
+
+import (
+	"github.com/sjmudd/ps-top/model"
+)
+
+// Transactions holds the open transactions
+type Transactions struct {
+	*model.BaseCollector[Row, Rows]
+}
+
+// NewTransactions creates a new Transactions instance.
+func NewTransactions(cfg model.Config, db model.QueryExecutor) *Transactions {
+	process := func(last, _ Rows) (Rows, Row) {
+		results := make(Rows, len(last))
+		copy(results, last)
+		tot := totals(results)
+		return results, tot
+	}
+	bc := model.NewBaseCollector[Row, Rows](cfg, db, process)
+	return &Transactions{BaseCollector: bc}
+}
+
+// Collect collects the transactions open now. There is nothing to
+// compare with previous collections so the values are always absolute.
+func (t *Transactions) Collect() {
+	bc := t.BaseCollector
+	fetch := func() (Rows, error) {
+		return collect(bc.DB()), nil
+	}
+	wantRefresh := func() bool {
+		return true
+	}
+	bc.Collect(fetch, wantRefresh)
+}
+
+// HaveRelativeStats returns false as the transactions are only seen while open
+func (t Transactions) HaveRelativeStats() bool {
+	return false
+}
+
+// WantRelativeStats returns whether relative stats are desired based on config
+func (t Transactions) WantRelativeStats() bool {
+	return t.Config().WantRelativeStats()
+}
@@ -4,8 +4,8 @@ package userlatency
 // Row contains a summary row of information taken from information_schema.processlist
 type Row struct {
 	Username    string
-	Runtime     uint64
-	Sleeptime   uint64
+	Runtime     uint64 // picoseconds, the largest value if too large
+	Sleeptime   uint64 // picoseconds, the largest value if too large
 	Connections uint64
 	Active      uint64
 	Hosts       uint64
@@ -19,7 +19,7 @@ type Row struct {
 
 // TotalTime returns Runtime + Sleeptime
 func (r Row) TotalTime() uint64 {
-	return r.Runtime + r.Sleeptime
+	return addTime(r.Runtime, r.Sleeptime)
 }
 
 // totals returns the totals of all rows
@@ -27,8 +27,8 @@ func totals(rows []Row) Row {
 	total := Row{Username: "Totals"}
 
 	for _, row := range rows {
-		total.Runtime += row.Runtime
-		total.Sleeptime += row.Sleeptime
+		total.Runtime = addTime(total.Runtime, row.Runtime)
+		total.Sleeptime = addTime(total.Sleeptime, row.Sleeptime)
 		total.Connections += row.Connections
 		total.Active += row.Active
 		total.Selects += row.Selects
@@ -2,11 +2,15 @@
 package userlatency
 
 import (
+	"math"
 	"regexp"
 	"strings"
 
+	"github.com/sjmudd/ps-top/log"
 	"github.com/sjmudd/ps-top/model"
 	"github.com/sjmudd/ps-top/model/processlist"
+	"github.com/sjmudd/ps-top/model/runningstatements"
+	"github.com/sjmudd/ps-top/utils"
 )
 
 type mapStringInt map[string]int
@@ -34,7 +38,12 @@ func (ul *UserLatency) Collect() {
 	bc := ul.BaseCollector
 	fetch := func() ([]Row, error) {
 		raw := processlist.Collect(bc.DB())
-		aggregated := ul.processlist2byUser(raw)
+		// without the runtimes the processlist times are used
+		running, err := runningstatements.Collect(bc.DB(), nil)
+		if err != nil {
+			log.Printf("userlatency.Collect: no runtimes: %v", err)
+		}
+		aggregated := ul.processlist2byUser(raw, runtimes(running))
 		return aggregated, nil
 	}
 	wantRefresh := func() bool {
@@ -44,8 +53,21 @@ func (ul *UserLatency) Collect() {
 	bc.Collect(fetch, wantRefresh)
 }
 
-// processlist2byUser aggregates raw processlist rows by username
-func (ul *UserLatency) processlist2byUser(raw []processlist.Row) []Row {
+// runtimes returns how long each connection has been running its
+// current statement in picoseconds, keyed by processlist id
+func runtimes(running runningstatements.Rows) map[uint64]uint64 {
+	runtime := make(map[uint64]uint64)
+	for _, r := range running {
+		// a stored program has a row for each nested statement
+		runtime[r.ProcesslistID] = max(runtime[r.ProcesslistID], r.TimerWait)
+	}
+	return runtime
+}
+
+// processlist2byUser aggregates raw processlist rows by username. Times
+// are in picoseconds, using the runtimes of the running statements when
+// available as processlist.TIME only has a resolution of one second.
+func (ul *UserLatency) processlist2byUser(raw []processlist.Row, runtime map[uint64]uint64) []Row {
 	reActiveReplMasterThread := regexp.MustCompile("Sending binlog event to slave")
 	reSelect := regexp.MustCompile(`(?i)SELECT`)
 	reInsert := regexp.MustCompile(`(?i)INSERT`)
@@ -78,7 +100,11 @@ func (ul *UserLatency) processlist2byUser(raw []processlist.Row) []Row {
 		r := getOrCreateRow(rowByUser, username, pl.User)
 		r.Connections++
 
-		updateRuntimeAndActive(r, command, pl.Time, host, state, reActiveReplMasterThread)
+		t := picoseconds(pl.Time)
+		if rt, ok := runtime[pl.ID]; ok && command != "Sleep" {
+			t = rt
+		}
+		updateRuntimeAndActive(r, command, t, host, state, reActiveReplMasterThread)
 
 		// track hosts and dbs per user
 		r.Hosts = addHost(hostsByUser, username, host)
@@ -110,9 +136,9 @@ func getOrCreateRow(rowByUser map[string]*Row, username, origUser string) *Row {
 func updateRuntimeAndActive(r *Row, command string, t uint64, host, state string, reActive *regexp.Regexp) {
 	if r.Username != "system user" && host != "" && command != "Binlog Dump" {
 		if command == "Sleep" {
-			r.Sleeptime += t
+			r.Sleeptime = addTime(r.Sleeptime, t)
 		} else {
-			r.Runtime += t
+			r.Runtime = addTime(r.Runtime, t)
 			r.Active++
 		}
 	}
@@ -121,6 +147,26 @@ func updateRuntimeAndActive(r *Row, command string, t uint64, host, state string
 	}
 }
 
+// picoseconds returns the processlist time in picoseconds. The times of
+// long running threads, such as the event scheduler, may not fit so the
+// largest time is used instead.
+func picoseconds(seconds uint64) uint64 {
+	if seconds > math.MaxUint64/utils.PicosecondsPerSecond {
+		return math.MaxUint64
+	}
+	return seconds * utils.PicosecondsPerSecond
+}
+
+// addTime returns the sum of two times in picoseconds, the largest time
+// if the sum does not fit. Many idle connections soon add up to more
+// than the roughly 213 days which fit.
+func addTime(a, b uint64) uint64 {
+	if sum := a + b; sum >= a {
+		return sum
+	}
+	return math.MaxUint64
+}
+
 // helper: add host to hostsByUser and return count of distinct hosts for user
 func addHost(hostsByUser map[string]mapStringInt, username, host string) uint64 {
 	if host == "" {
@@ -0,0 +1,77 @@
+package userlatency
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sjmudd/ps-top/model/processlist"
+	"github.com/sjmudd/ps-top/model/runningstatements"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// TestProcesslist2byUserRuntimes checks the runtimes of running statements
+// replace the processlist times, which are in whole seconds.
+func TestProcesslist2byUserRuntimes(t *testing.T) {
+	raw := []processlist.Row{
+		{ID: 1, User: "app", Host: "10.0.0.1:1234", Command: "Query", Time: 0},
+		{ID: 2, User: "app", Host: "10.0.0.2:1234", Command: "Query", Time: 3},
+		{ID: 3, User: "app", Host: "10.0.0.1:1235", Command: "Sleep", Time: 5},
+	}
+	running := runningstatements.Rows{
+		{ThreadID: 40, ProcesslistID: 1, TimerWait: 250000000},
+		// a nested statement of a stored program
+		{ThreadID: 40, ProcesslistID: 1, TimerWait: 150000000},
+	}
+
+	var ul UserLatency
+	rows := ul.processlist2byUser(raw, runtimes(running))
+	if len(rows) != 1 {
+		t.Fatalf("processlist2byUser returned %d rows, want 1", len(rows))
+	}
+	if want := uint64(250000000 + 3*utils.PicosecondsPerSecond); rows[0].Runtime != want {
+		t.Errorf("Runtime = %d, want %d", rows[0].Runtime, want)
+	}
+	if want := uint64(5 * utils.PicosecondsPerSecond); rows[0].Sleeptime != want {
+		t.Errorf("Sleeptime = %d, want %d", rows[0].Sleeptime, want)
+	}
+	if rows[0].Active != 2 || rows[0].Connections != 3 {
+		t.Errorf("Active, Connections = %d, %d, want 2, 3", rows[0].Active, rows[0].Connections)
+	}
+}
+
+// TestProcesslist2byUserLargeTimes checks the times of many idle
+// connections and of long running threads do not wrap around.
+func TestProcesslist2byUserLargeTimes(t *testing.T) {
+	const eightHours = 8 * 3600
+	var raw []processlist.Row
+	for i := range 1000 {
+		raw = append(raw, processlist.Row{ID: uint64(i + 1), User: "app", Host: "10.0.0.1:1234", Command: "Sleep", Time: eightHours})
+	}
+	raw = append(raw,
+		// running for longer than the picoseconds which fit
+		processlist.Row{ID: 2000, User: "event_scheduler", Host: "localhost", Command: "Daemon", Time: 300 * 24 * 3600},
+		processlist.Row{ID: 2001, User: "repl", Host: "10.0.0.2:1234", Command: "Query", Time: 100 * 24 * 3600},
+		processlist.Row{ID: 2002, User: "repl", Host: "10.0.0.3:1234", Command: "Query", Time: 150 * 24 * 3600},
+	)
+
+	var ul UserLatency
+	byUser := make(map[string]Row)
+	for _, row := range ul.processlist2byUser(raw, nil) {
+		byUser[row.Username] = row
+	}
+
+	for _, user := range []string{"app", "event_scheduler", "repl"} {
+		row := byUser[user]
+		if row.TotalTime() != math.MaxUint64 {
+			t.Errorf("%s: TotalTime() = %d, want the largest time %d", user, row.TotalTime(), uint64(math.MaxUint64))
+		}
+	}
+	if byUser["app"].Sleeptime != math.MaxUint64 {
+		t.Errorf("app: Sleeptime = %d, want the largest time", byUser["app"].Sleeptime)
+	}
+
+	total := totals([]Row{byUser["app"], byUser["repl"]})
+	if total.Runtime != math.MaxUint64 || total.Sleeptime != math.MaxUint64 {
+		t.Errorf("totals = %d, %d, want the largest times", total.Runtime, total.Sleeptime)
+	}
+}
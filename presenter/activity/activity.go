@@ -0,0 +1,108 @@
+// Package activity holds the routines which manage the statement activity of each account or host.
+package activity
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/activity"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(activity.Row) uint64) func(a, b activity.Row) int {
+	return presenter.ByValue(value, func(r activity.Row) string { return r.Name })
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[activity.Row]{
+		{Heading: "Latency", Compare: byValue(func(r activity.Row) uint64 { return r.SumTimerWait })},
+		{Heading: "Execs", Compare: byValue(func(r activity.Row) uint64 { return r.CountStar })},
+		{Heading: "RowsExam", Compare: byValue(func(r activity.Row) uint64 { return r.SumRowsExamined })},
+		{Heading: "RowsSent", Compare: byValue(func(r activity.Row) uint64 { return r.SumRowsSent })},
+		{Heading: "Conn", Compare: byValue(func(r activity.Row) uint64 { return r.CurrentConnections })},
+		{Heading: "TotConn", Compare: byValue(func(r activity.Row) uint64 { return r.TotalConnections })},
+		{Heading: "Name", Compare: presenter.ByName(func(r activity.Row) string { return r.Name })},
+	}
+
+	defaultHasData = func(r activity.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, totals activity.Row) string {
+		name := row.Name
+		if !row.HasData() && name != "Totals" {
+			name = ""
+		}
+		return fmt.Sprintf("%10s %6s %8s|%6s %6s %6s %6s %6s|%8s %8s|%5s %8s|%s",
+			utils.FormatTime(row.SumTimerWait),
+			utils.FormatPct(utils.Divide(row.SumTimerWait, totals.SumTimerWait)),
+			utils.FormatAmount(row.CountStar),
+			utils.FormatPct(utils.Divide(row.Selects, row.CountStar)),
+			utils.FormatPct(utils.Divide(row.Inserts, row.CountStar)),
+			utils.FormatPct(utils.Divide(row.Updates, row.CountStar)),
+			utils.FormatPct(utils.Divide(row.Deletes, row.CountStar)),
+			utils.FormatPct(utils.Divide(row.Other(), row.CountStar)),
+			utils.FormatAmount(row.SumRowsExamined),
+			utils.FormatAmount(row.SumRowsSent),
+			utils.FormatAmount(row.CurrentConnections),
+			utils.FormatAmount(row.TotalConnections),
+			name)
+	}
+
+	defaultColumns = []presenter.Column[activity.Row]{
+		{Name: "name", Value: func(r activity.Row) any { return r.Name }},
+		{Name: "sum_timer_wait", Value: func(r activity.Row) any { return r.SumTimerWait }},
+		{Name: "count_star", Value: func(r activity.Row) any { return r.CountStar }},
+		{Name: "count_select", Value: func(r activity.Row) any { return r.Selects }},
+		{Name: "count_insert", Value: func(r activity.Row) any { return r.Inserts }},
+		{Name: "count_update", Value: func(r activity.Row) any { return r.Updates }},
+		{Name: "count_delete", Value: func(r activity.Row) any { return r.Deletes }},
+		{Name: "count_other", Value: func(r activity.Row) any { return r.Other() }},
+		{Name: "sum_rows_examined", Value: func(r activity.Row) any { return r.SumRowsExamined }},
+		{Name: "sum_rows_sent", Value: func(r activity.Row) any { return r.SumRowsSent }},
+		{Name: "current_connections", Value: func(r activity.Row) any { return r.CurrentConnections }},
+		{Name: "total_connections", Value: func(r activity.Row) any { return r.TotalConnections }},
+	}
+)
+
+// Presenter presents an Activity struct.
+type Presenter struct {
+	*presenter.BasePresenter[activity.Row, *activity.Activity]
+	nameHeading string // "Account" or "Host"
+}
+
+// NewAccountActivity creates a presenter for the statement activity of each account.
+func NewAccountActivity(cfg model.Config, db *sql.DB) *Presenter {
+	return newPresenter(
+		activity.NewAccountActivity(cfg, db),
+		"Account Activity (events_statements_summary_by_account_by_event_name)",
+		"Account")
+}
+
+// NewHostActivity creates a presenter for the statement activity of each host.
+func NewHostActivity(cfg model.Config, db *sql.DB) *Presenter {
+	return newPresenter(
+		activity.NewHostActivity(cfg, db),
+		"Host Activity (events_statements_summary_by_host_by_event_name)",
+		"Host")
+}
+
+func newPresenter(model *activity.Activity, description, nameHeading string) *Presenter {
+	bp := presenter.NewBasePresenter(
+		model,
+		description,
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r activity.Row) string { return r.Name },
+	)
+	return &Presenter{BasePresenter: bp, nameHeading: nameHeading}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %6s %8s|%6s %6s %6s %6s %6s|%8s %8s|%5s %8s|%s",
+		"Latency", "%", "Execs", "Select", "Insert", "Update", "Delete", "Other", "RowsExam", "RowsSent", "Conn", "TotConn", p.nameHeading)
+}
@@ -2,6 +2,8 @@ package presenter
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"time"
 
 	"github.com/sjmudd/ps-top/model"
@@ -12,35 +14,91 @@ import (
 type BasePresenter[T any, M model.Model[T]] struct {
 	model     M
 	name      string
-	sortFn    func([]T)         // optional sorting function; nil = no sort
+	sortKeys  []SortKey[T]      // possible orderings of the rows; empty = no sort
+	sortIndex int               // index of the sort key in use
+	reverse   bool              // reverse the order given by the sort key?
 	hasData   func(T) bool      // predicate for counting rows with data; nil = count all rows
 	contentFn func(T, T) string // formats a single row
+	columns   []Column[T]       // raw data columns used when exporting rows
+	rowName   func(T) string    // name of a row used when filtering; nil = rows can not be filtered
+	rowKey    func(T) string    // unique key of a row used when selecting it; nil = the name is unique
+	pattern   string            // filter pattern as provided by the user
+	filter    *regexp.Regexp    // only rows whose names match are shown; nil = show all rows
+	selected  string            // name of the selected row; empty = no row selected
 }
 
 // NewBasePresenter creates a new BasePresenter with the given model and options.
 func NewBasePresenter[T any, M model.Model[T]](
 	model M,
 	name string,
-	sortFn func([]T),
+	sortKeys []SortKey[T],
 	hasData func(T) bool,
 	contentFn func(T, T) string,
+	columns []Column[T],
+	rowName func(T) string,
 ) *BasePresenter[T, M] {
 	return &BasePresenter[T, M]{
 		model:     model,
 		name:      name,
-		sortFn:    sortFn,
+		sortKeys:  sortKeys,
 		hasData:   hasData,
 		contentFn: contentFn,
+		columns:   columns,
+		rowName:   rowName,
 	}
 }
 
 // Collect implements Tabler.
 func (bp *BasePresenter[T, M]) Collect() {
 	bp.model.Collect()
-	if bp.sortFn != nil {
-		results := bp.model.GetResults()
-		bp.sortFn(results)
+	bp.sort()
+}
+
+// sort orders the model's results using the current sort key.
+func (bp *BasePresenter[T, M]) sort() {
+	if len(bp.sortKeys) == 0 {
+		return
+	}
+	compare := bp.sortKeys[bp.sortIndex].Compare
+	if bp.reverse {
+		slices.SortFunc(bp.model.GetResults(), func(a, b T) int { return compare(b, a) })
+	} else {
+		slices.SortFunc(bp.model.GetResults(), compare)
+	}
+}
+
+// Sort orders the model's results using the current sort key. It is
+// needed if the results change other than by collecting them.
+func (bp *BasePresenter[T, M]) Sort() {
+	bp.sort()
+}
+
+// NextSortKey changes to the next sort key (wrapping around) and sorts the rows.
+func (bp *BasePresenter[T, M]) NextSortKey() {
+	if len(bp.sortKeys) == 0 {
+		return
+	}
+	bp.sortIndex = (bp.sortIndex + 1) % len(bp.sortKeys)
+	bp.sort()
+}
+
+// ReverseSort toggles reversing the sort order and sorts the rows.
+func (bp *BasePresenter[T, M]) ReverseSort() {
+	bp.reverse = !bp.reverse
+	bp.sort()
+}
+
+// SortHeading returns the heading of the column being sorted on.
+func (bp *BasePresenter[T, M]) SortHeading() string {
+	if len(bp.sortKeys) == 0 {
+		return ""
 	}
+	return bp.sortKeys[bp.sortIndex].Heading
+}
+
+// SortReversed returns true if the sort order is reversed.
+func (bp *BasePresenter[T, M]) SortReversed() bool {
+	return bp.reverse
 }
 
 // ResetStatistics implements Tabler.
@@ -68,19 +126,172 @@ func (bp *BasePresenter[T, M]) WantRelativeStats() bool {
 	return bp.model.WantRelativeStats()
 }
 
+// SetFilter only shows rows whose names match the given pattern, ignoring case.
+// The pattern is a regular expression but if it is not valid it is used
+// as a plain substring. An empty pattern shows all rows.
+func (bp *BasePresenter[T, M]) SetFilter(pattern string) {
+	bp.pattern = pattern
+	bp.filter = nil
+	if pattern == "" || bp.rowName == nil {
+		return
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+	}
+	bp.filter = re
+}
+
+// Filter returns the pattern used to filter rows, empty if rows are not filtered.
+func (bp *BasePresenter[T, M]) Filter() string {
+	if bp.filter == nil {
+		return ""
+	}
+	return bp.pattern
+}
+
+// results returns the model's results whose names match the filter.
+func (bp *BasePresenter[T, M]) results() []T {
+	results := bp.model.GetResults()
+	if bp.filter == nil {
+		return results
+	}
+	filtered := make([]T, 0, len(results))
+	for i := range results {
+		if bp.filter.MatchString(bp.rowName(results[i])) {
+			filtered = append(filtered, results[i])
+		}
+	}
+	return filtered
+}
+
+// SetRowKey sets the function returning the unique key of a row. It is
+// used instead of the row's name to select the row and find its detail
+// for rows whose names may not be unique, e.g. the statements running now.
+func (bp *BasePresenter[T, M]) SetRowKey(rowKey func(T) string) {
+	bp.rowKey = rowKey
+}
+
+// key returns the unique key of a row
+func (bp *BasePresenter[T, M]) key(row T) string {
+	if bp.rowKey != nil {
+		return bp.rowKey(row)
+	}
+	return bp.rowName(row)
+}
+
+// selectedIndex returns the index of the selected row in results, -1 if not found.
+func (bp *BasePresenter[T, M]) selectedIndex(results []T) int {
+	if bp.selected == "" || bp.rowName == nil {
+		return -1
+	}
+	for i := range results {
+		if bp.key(results[i]) == bp.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectIndex selects the row at the given position of the shown rows
+// limiting it to the rows available.
+func (bp *BasePresenter[T, M]) selectIndex(results []T, i int) {
+	if len(results) == 0 || bp.rowName == nil {
+		return
+	}
+	i = max(0, min(i, len(results)-1))
+	bp.selected = bp.key(results[i])
+}
+
+// MoveSelection moves the selected row down (or up if delta is negative)
+// by delta rows. If no row is selected moving down starts at the first
+// row and moving up starts at the last row.
+func (bp *BasePresenter[T, M]) MoveSelection(delta int) {
+	results := bp.results()
+	i := bp.selectedIndex(results)
+	if i < 0 {
+		if delta > 0 {
+			i = -1
+		} else {
+			i = len(results)
+		}
+	}
+	bp.selectIndex(results, i+delta)
+}
+
+// SelectFirst selects the first row shown.
+func (bp *BasePresenter[T, M]) SelectFirst() {
+	bp.selectIndex(bp.results(), 0)
+}
+
+// SelectLast selects the last row shown.
+func (bp *BasePresenter[T, M]) SelectLast() {
+	results := bp.results()
+	bp.selectIndex(results, len(results)-1)
+}
+
+// SelectedRow returns the index in RowContent() of the selected row, -1 if
+// no row is selected. The selection follows the row's key so it remains
+// on the same row when the rows are collected again or sorted differently.
+func (bp *BasePresenter[T, M]) SelectedRow() int {
+	return bp.selectedIndex(bp.results())
+}
+
+// SelectedName returns the key of the selected row, usually its name, empty
+// if no row is selected.
+func (bp *BasePresenter[T, M]) SelectedName() string {
+	if bp.SelectedRow() < 0 {
+		return ""
+	}
+	return bp.selected
+}
+
+// findRow returns the row with the given key, usually its name.
+func (bp *BasePresenter[T, M]) findRow(rows []T, name string) (T, bool) {
+	for i := range rows {
+		if bp.key(rows[i]) == name {
+			return rows[i], true
+		}
+	}
+	var empty T
+	return empty, false
+}
+
+// Detail returns lines describing every field of the named row with both
+// absolute and relative values. nil is returned if there is no such row.
+func (bp *BasePresenter[T, M]) Detail(name string) []string {
+	if bp.rowName == nil {
+		return nil
+	}
+	last, _ := bp.model.Snapshot()
+	row, found := bp.findRow(last, name)
+	if !found {
+		return nil
+	}
+	first, _ := bp.model.Baseline()
+	baseline, _ := bp.findRow(first, name)
+
+	return Detail(bp.name, row, baseline, bp.model.HaveRelativeStats())
+}
+
 // RowContent implements Tabler.
 func (bp *BasePresenter[T, M]) RowContent() []string {
-	results := bp.model.GetResults()
+	results := bp.results()
 	n := len(results)
 	return RowsFromGetter(n, func(i int) string {
 		return bp.contentFn(results[i], bp.model.GetTotals())
 	})
 }
 
-// TotalRowContent implements Tabler.
+// TotalRowContent implements Tabler. The totals are of all the rows, not
+// just those shown, so they are marked as unfiltered if a filter is set.
 func (bp *BasePresenter[T, M]) TotalRowContent() string {
 	totals := bp.model.GetTotals()
-	return TotalRowContent(totals, bp.contentFn)
+	content := TotalRowContent(totals, bp.contentFn)
+	if bp.filter != nil {
+		content += " (unfiltered)"
+	}
+	return content
 }
 
 // EmptyRowContent implements Tabler.
@@ -90,13 +301,69 @@ func (bp *BasePresenter[T, M]) EmptyRowContent() string {
 
 // Description implements Tabler.
 func (bp *BasePresenter[T, M]) Description() string {
-	results := bp.model.GetResults()
+	results := bp.results()
 	n := len(results)
 	count := n
 	if bp.hasData != nil {
 		count = CountIf(n, func(i int) bool { return bp.hasData(results[i]) })
 	}
-	return fmt.Sprintf("%s %d rows", bp.name, count)
+	description := fmt.Sprintf("%s %d rows", bp.name, count)
+	if heading := bp.SortHeading(); bp.sortIndex > 0 && heading != "" {
+		description += ", sorted by " + heading
+	}
+	if bp.reverse {
+		description += " (reversed)"
+	}
+	if filter := bp.Filter(); filter != "" {
+		description += fmt.Sprintf(", filter: %q", filter)
+	}
+	return description
+}
+
+// RawResults returns the current results using the model's own row type.
+// Used when exporting data in a machine readable format.
+func (bp *BasePresenter[T, M]) RawResults() any {
+	results := bp.results()
+	if results == nil {
+		results = []T{}
+	}
+	return results
+}
+
+// RawTotals returns the totals row using the model's own row type.
+func (bp *BasePresenter[T, M]) RawTotals() any {
+	return bp.model.GetTotals()
+}
+
+// ColumnNames returns the names of the raw data columns.
+func (bp *BasePresenter[T, M]) ColumnNames() []string {
+	return ColumnNames(bp.columns)
+}
+
+// ColumnValues returns the raw data column values for each result row.
+func (bp *BasePresenter[T, M]) ColumnValues() [][]string {
+	results := bp.results()
+	values := make([][]string, 0, len(results))
+	for i := range results {
+		values = append(values, ColumnValues(bp.columns, results[i]))
+	}
+	return values
+}
+
+// Snapshot returns the model's most recent raw data and when it was collected.
+func (bp *BasePresenter[T, M]) Snapshot() (any, time.Time) {
+	return bp.model.Snapshot()
+}
+
+// Replay provides previously recorded raw data to the model which
+// is used by later collections. rows must have the model's row type.
+func (bp *BasePresenter[T, M]) Replay(rows any, collected time.Time) error {
+	last, ok := rows.([]T)
+	if !ok {
+		return fmt.Errorf("%s: can not replay rows of type %T", bp.name, rows)
+	}
+	bp.model.Replay(last, collected)
+	return nil
 }
 
 // GetModel returns the embedded model. Used by special presenters like tableioops.
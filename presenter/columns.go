@@ -0,0 +1,32 @@
+package presenter
+
+import (
+	"fmt"
+)
+
+// Column describes a single column of raw (unformatted) row data.
+// Columns are used when exporting a view so values are provided as
+// collected, e.g. picoseconds or bytes, rather than as formatted text.
+type Column[T any] struct {
+	Name  string      // name of the column used in a header row
+	Value func(T) any // returns the raw value of the column for the given row
+}
+
+// ColumnNames returns the names of the given columns.
+func ColumnNames[T any](columns []Column[T]) []string {
+	names := make([]string, len(columns))
+	for i := range columns {
+		names[i] = columns[i].Name
+	}
+	return names
+}
+
+// ColumnValues returns the raw values of the given row for each column
+// formatted as strings.
+func ColumnValues[T any](columns []Column[T], row T) []string {
+	values := make([]string, len(columns))
+	for i := range columns {
+		values[i] = fmt.Sprint(columns[i].Value(row))
+	}
+	return values
+}
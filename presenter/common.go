@@ -3,6 +3,7 @@ package presenter
 import (
 	"fmt"
 
+	"github.com/sjmudd/ps-top/model/tableio"
 	"github.com/sjmudd/ps-top/utils"
 )
 
@@ -60,6 +61,25 @@ func MakeTableIOHeadings(kind string) string {
 		"Table Name")
 }
 
+// TableIOColumns are the raw data columns shared by the tableio presenters.
+var TableIOColumns = []Column[tableio.Row]{
+	{Name: "name", Value: func(r tableio.Row) any { return r.Name }},
+	{Name: "sum_timer_wait", Value: func(r tableio.Row) any { return r.SumTimerWait }},
+	{Name: "sum_timer_read", Value: func(r tableio.Row) any { return r.SumTimerRead }},
+	{Name: "sum_timer_write", Value: func(r tableio.Row) any { return r.SumTimerWrite }},
+	{Name: "sum_timer_fetch", Value: func(r tableio.Row) any { return r.SumTimerFetch }},
+	{Name: "sum_timer_insert", Value: func(r tableio.Row) any { return r.SumTimerInsert }},
+	{Name: "sum_timer_update", Value: func(r tableio.Row) any { return r.SumTimerUpdate }},
+	{Name: "sum_timer_delete", Value: func(r tableio.Row) any { return r.SumTimerDelete }},
+	{Name: "count_star", Value: func(r tableio.Row) any { return r.CountStar }},
+	{Name: "count_read", Value: func(r tableio.Row) any { return r.CountRead }},
+	{Name: "count_write", Value: func(r tableio.Row) any { return r.CountWrite }},
+	{Name: "count_fetch", Value: func(r tableio.Row) any { return r.CountFetch }},
+	{Name: "count_insert", Value: func(r tableio.Row) any { return r.CountInsert }},
+	{Name: "count_update", Value: func(r tableio.Row) any { return r.CountUpdate }},
+	{Name: "count_delete", Value: func(r tableio.Row) any { return r.CountDelete }},
+}
+
 // TimePct returns the formatted time and percentage strings for a row's
 // SumTimerWait and the total SumTimerWait. This small helper centralizes
 // the common prefix used by several wrapper content formatters.
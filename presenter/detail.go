@@ -0,0 +1,112 @@
+package presenter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// detailFormat is the format of each line describing a field of a row
+const detailFormat = "%-36s %14s %14s"
+
+// DetailHeadings returns the headings used when showing the detail of a row.
+func DetailHeadings() string {
+	return fmt.Sprintf(detailFormat, "Field", "Absolute", "Relative")
+}
+
+// Detail returns a title line followed by one line for each numeric field
+// of last showing its absolute value and, if haveRelative is true, its
+// value relative to first. The average latency of each SumTimer field with
+// a matching count (SumTimerWait uses CountStar) is shown at the end.
+func Detail[T any](title string, last, first T, haveRelative bool) []string {
+	lv, fv := reflect.ValueOf(last), reflect.ValueOf(first)
+	if lv.Kind() != reflect.Struct {
+		return nil
+	}
+	lt := lv.Type()
+
+	lines := []string{title}
+	counters := make(map[string][2]uint64) // absolute and relative values of unsigned fields
+	for i := 0; i < lt.NumField(); i++ {
+		field := lt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		var absolute, relative string
+		switch field.Type.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			a, f := lv.Field(i).Uint(), fv.Field(i).Uint()
+			r := a // the counter has been reset so the baseline is not useful
+			if a >= f && !strings.HasPrefix(field.Name, "Max") {
+				r = a - f // maximums are not counters so are shown as they are
+			}
+			counters[field.Name] = [2]uint64{a, r}
+			absolute, relative = formatDetail(field.Name, a), formatDetail(field.Name, r)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			a, f := lv.Field(i).Int(), fv.Field(i).Int()
+			absolute, relative = formatSignedDetail(field.Name, a), formatSignedDetail(field.Name, a-f)
+		default:
+			continue
+		}
+		if !haveRelative {
+			relative = ""
+		}
+		lines = append(lines, fmt.Sprintf(detailFormat, field.Name, absolute, relative))
+	}
+
+	for i := 0; i < lt.NumField(); i++ {
+		operation, found := strings.CutPrefix(lt.Field(i).Name, "SumTimer")
+		if !found {
+			continue
+		}
+		count := "Count" + operation
+		if operation == "Wait" {
+			count = "CountStar"
+		}
+		sums, haveSum := counters[lt.Field(i).Name]
+		counts, haveCount := counters[count]
+		if !haveSum || !haveCount {
+			continue
+		}
+		relative := ""
+		if haveRelative {
+			relative = utils.FormatTime(average(sums[1], counts[1]))
+		}
+		lines = append(lines, fmt.Sprintf(detailFormat, "Average "+operation+" latency", utils.FormatTime(average(sums[0], counts[0])), relative))
+	}
+
+	return lines
+}
+
+// average returns sum / count or 0 if count is 0
+func average(sum, count uint64) uint64 {
+	if count == 0 {
+		return 0
+	}
+	return sum / count
+}
+
+// formatDetail formats the value of the named field based on its name:
+// timers are in picoseconds, bytes are shown with a suffix and anything else
+// is shown as a plain number.
+func formatDetail(name string, value uint64) string {
+	switch {
+	case strings.HasPrefix(name, "SumTimer"), strings.HasPrefix(name, "MaxTimer"):
+		return utils.FormatTime(value)
+	case strings.Contains(name, "Bytes"):
+		return utils.FormatAmount(value)
+	}
+	return strconv.FormatUint(value, 10)
+}
+
+// formatSignedDetail formats a signed value in the same way as formatDetail
+func formatSignedDetail(name string, value int64) string {
+	if strings.Contains(name, "Bytes") {
+		return utils.SignedFormatAmount(value)
+	}
+	return strconv.FormatInt(value, 10)
+}
@@ -1,4 +1,5 @@
-// Package fileinfolatency holds the routines which manage the file_summary_by_instance table.
+// Package fileinfolatency holds the routines which manage the file_summary_by_instance
+// and file_summary_by_event_name tables, and the equivalent socket tables.
 package fileinfolatency
 
 import (
@@ -12,14 +13,21 @@ import (
 	"github.com/sjmudd/ps-top/utils"
 )
 
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(fileinfo.Row) uint64) func(a, b fileinfo.Row) int {
+	return presenter.ByValue(value, func(r fileinfo.Row) string { return r.Name })
+}
+
 var (
-	defaultSort = func(rows []fileinfo.Row) {
-		slices.SortFunc(rows, func(a, b fileinfo.Row) int {
-			return utils.SumTimerWaitNameOrdering(
-				utils.NewSumTimerWaitName(a.Name, a.SumTimerWait),
-				utils.NewSumTimerWaitName(b.Name, b.SumTimerWait),
-			)
-		})
+	defaultSortKeys = []presenter.SortKey[fileinfo.Row]{
+		{Heading: "Latency", Compare: byValue(func(r fileinfo.Row) uint64 { return r.SumTimerWait })},
+		{Heading: "Read", Compare: byValue(func(r fileinfo.Row) uint64 { return r.SumTimerRead })},
+		{Heading: "Write", Compare: byValue(func(r fileinfo.Row) uint64 { return r.SumTimerWrite })},
+		{Heading: "Misc", Compare: byValue(func(r fileinfo.Row) uint64 { return r.SumTimerMisc })},
+		{Heading: "Rd bytes", Compare: byValue(func(r fileinfo.Row) uint64 { return r.SumNumberOfBytesRead })},
+		{Heading: "Wr bytes", Compare: byValue(func(r fileinfo.Row) uint64 { return r.SumNumberOfBytesWrite })},
+		{Heading: "Ops", Compare: byValue(func(r fileinfo.Row) uint64 { return r.CountStar })},
+		{Heading: "Table Name", Compare: presenter.ByName(func(r fileinfo.Row) string { return r.Name })},
 	}
 
 	defaultHasData = func(r fileinfo.Row) bool { return r.HasData() }
@@ -51,24 +59,91 @@ var (
 			opsPct[2],
 			name)
 	}
+
+	defaultColumns = []presenter.Column[fileinfo.Row]{
+		{Name: "name", Value: func(r fileinfo.Row) any { return r.Name }},
+		{Name: "sum_timer_wait", Value: func(r fileinfo.Row) any { return r.SumTimerWait }},
+		{Name: "sum_timer_read", Value: func(r fileinfo.Row) any { return r.SumTimerRead }},
+		{Name: "sum_timer_write", Value: func(r fileinfo.Row) any { return r.SumTimerWrite }},
+		{Name: "sum_timer_misc", Value: func(r fileinfo.Row) any { return r.SumTimerMisc }},
+		{Name: "sum_number_of_bytes_read", Value: func(r fileinfo.Row) any { return r.SumNumberOfBytesRead }},
+		{Name: "sum_number_of_bytes_write", Value: func(r fileinfo.Row) any { return r.SumNumberOfBytesWrite }},
+		{Name: "count_star", Value: func(r fileinfo.Row) any { return r.CountStar }},
+		{Name: "count_read", Value: func(r fileinfo.Row) any { return r.CountRead }},
+		{Name: "count_write", Value: func(r fileinfo.Row) any { return r.CountWrite }},
+		{Name: "count_misc", Value: func(r fileinfo.Row) any { return r.CountMisc }},
+	}
 )
 
+// sortKeys returns the default sort keys with the name column using the
+// given heading so that it is highlighted when sorting by name.
+func sortKeys(nameHeading string) []presenter.SortKey[fileinfo.Row] {
+	keys := slices.Clone(defaultSortKeys)
+	keys[len(keys)-1].Heading = nameHeading
+	return keys
+}
+
 // Presenter presents a FileIoLatency struct.
 type Presenter struct {
 	*presenter.BasePresenter[fileinfo.Row, *fileinfo.FileIoLatency]
+	nameHeading string // "Table Name", "Category", "Event Name", "Socket Type" or "Address"
 }
 
 // NewFileSummaryByInstance creates a presenter for FileIoLatency.
 func NewFileSummaryByInstance(cfg model.Config, db *sql.DB) *Presenter {
-	fiol := fileinfo.NewFileSummaryByInstance(cfg, db)
-	bp := presenter.NewBasePresenter(
-		fiol,
+	return newPresenter(
+		fileinfo.NewFileSummaryByInstance(cfg, db),
 		"File I/O Latency (file_summary_by_instance)",
-		defaultSort,
+		"Table Name")
+}
+
+// NewFileSummaryByCategory creates a presenter for FileIoLatency showing
+// the file I/O of each category of file.
+func NewFileSummaryByCategory(cfg model.Config, db *sql.DB) *Presenter {
+	return newPresenter(
+		fileinfo.NewFileSummaryByCategory(cfg, db),
+		"File I/O by Category (file_summary_by_instance)",
+		"Category")
+}
+
+// NewFileSummaryByEventName creates a presenter for FileIoLatency showing
+// the file I/O of each file event.
+func NewFileSummaryByEventName(cfg model.Config, db *sql.DB) *Presenter {
+	return newPresenter(
+		fileinfo.NewFileSummaryByEventName(cfg, db),
+		"File I/O by Event (file_summary_by_event_name)",
+		"Event Name")
+}
+
+// NewSocketSummaryByType creates a presenter for FileIoLatency showing the
+// socket I/O of each type of socket.
+func NewSocketSummaryByType(cfg model.Config, db *sql.DB) *Presenter {
+	return newPresenter(
+		fileinfo.NewSocketSummaryByType(cfg, db),
+		"Socket I/O by Type (socket_summary_by_event_name)",
+		"Socket Type")
+}
+
+// NewSocketSummaryByAddress creates a presenter for FileIoLatency showing
+// the socket I/O of the client connections from each remote address.
+func NewSocketSummaryByAddress(cfg model.Config, db *sql.DB) *Presenter {
+	return newPresenter(
+		fileinfo.NewSocketSummaryByAddress(cfg, db),
+		"Socket I/O by Address (socket_summary_by_instance)",
+		"Address")
+}
+
+func newPresenter(model *fileinfo.FileIoLatency, description, nameHeading string) *Presenter {
+	bp := presenter.NewBasePresenter(
+		model,
+		description,
+		sortKeys(nameHeading),
 		defaultHasData,
 		defaultContent,
+		defaultColumns,
+		func(r fileinfo.Row) string { return r.Name },
 	)
-	return &Presenter{BasePresenter: bp}
+	return &Presenter{BasePresenter: bp, nameHeading: nameHeading}
 }
 
 // Headings returns the headings for a table.
@@ -85,5 +160,5 @@ func (p *Presenter) Headings() string {
 		"R Ops",
 		"W Ops",
 		"M Ops",
-		"Table Name")
+		p.nameHeading)
 }
@@ -0,0 +1,82 @@
+// Package globalstatus holds the routines which manage the rates of the global status counters.
+package globalstatus
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/globalstatus"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue[V uint64 | float64](value func(globalstatus.Row) V) func(a, b globalstatus.Row) int {
+	return presenter.ByValue(value, func(r globalstatus.Row) string { return r.Name })
+}
+
+// formatRate formats a rate per second, with 2 decimal places if small.
+// Zero is returned as an empty string.
+func formatRate(rate float64) string {
+	switch {
+	case rate == 0:
+		return ""
+	case rate < 1024:
+		return fmt.Sprintf("%.2f", rate)
+	}
+	return utils.FormatAmount(uint64(rate))
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[globalstatus.Row]{
+		{Heading: "Rate/s", Compare: byValue(func(r globalstatus.Row) float64 { return r.PerSecond })},
+		{Heading: "Value", Compare: byValue(func(r globalstatus.Row) uint64 { return r.Value })},
+		{Heading: "Variable Name", Compare: presenter.ByName(func(r globalstatus.Row) string { return r.Name })},
+	}
+
+	defaultHasData = func(r globalstatus.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, _ globalstatus.Row) string {
+		name := row.Name
+		if row.Gauge {
+			name += " (now)"
+		}
+		return fmt.Sprintf("%10s %10s|%s",
+			formatRate(row.PerSecond),
+			utils.FormatAmount(row.Value),
+			name)
+	}
+
+	defaultColumns = []presenter.Column[globalstatus.Row]{
+		{Name: "variable_name", Value: func(r globalstatus.Row) any { return r.Name }},
+		{Name: "variable_value", Value: func(r globalstatus.Row) any { return r.Value }},
+		{Name: "gauge", Value: func(r globalstatus.Row) any { return r.Gauge }},
+		{Name: "per_second", Value: func(r globalstatus.Row) any { return r.PerSecond }},
+	}
+)
+
+// Presenter presents a GlobalStatus struct.
+type Presenter struct {
+	*presenter.BasePresenter[globalstatus.Row, *globalstatus.GlobalStatus]
+}
+
+// NewGlobalStatus creates a presenter for globalstatus.
+func NewGlobalStatus(cfg model.Config, db *sql.DB) *Presenter {
+	gs := globalstatus.NewGlobalStatus(cfg, db)
+	bp := presenter.NewBasePresenter(
+		gs,
+		"Global Status Rates (global_status)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r globalstatus.Row) string { return r.Name },
+	)
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %10s|%s", "Rate/s", "Value", "Variable Name")
+}
@@ -0,0 +1,75 @@
+// Package indexusage holds the routines which manage table I/O latency statistics by index.
+package indexusage
+
+import (
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model/tableio"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(tableio.Row) uint64) func(a, b tableio.Row) int {
+	return presenter.ByValue(value, func(r tableio.Row) string { return r.Name })
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[tableio.Row]{
+		{Heading: "Latency", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerWait })},
+		{Heading: "Ops", Compare: byValue(func(r tableio.Row) uint64 { return r.CountStar })},
+		{Heading: "Read", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerRead })},
+		{Heading: "Write", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerWrite })},
+		{Heading: "Fetch", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerFetch })},
+		{Heading: "Insert", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerInsert })},
+		{Heading: "Update", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerUpdate })},
+		{Heading: "Delete", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerDelete })},
+		{Heading: "Index Name", Compare: presenter.ByName(func(r tableio.Row) string { return r.Name })},
+	}
+
+	defaultHasData = func(r tableio.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, totals tableio.Row) string {
+		// assume the data is empty so hide it.
+		name := row.Name
+		if row.CountStar == 0 && name != "Totals" {
+			name = ""
+		}
+		return fmt.Sprintf("%10s %6s %10s|%6s %6s|%6s %6s %6s %6s|%s",
+			utils.FormatTime(row.SumTimerWait),
+			utils.FormatPct(utils.Divide(row.SumTimerWait, totals.SumTimerWait)),
+			utils.FormatCounterU(row.CountStar, 10),
+			utils.FormatPct(utils.Divide(row.SumTimerRead, row.SumTimerWait)),
+			utils.FormatPct(utils.Divide(row.SumTimerWrite, row.SumTimerWait)),
+			utils.FormatPct(utils.Divide(row.SumTimerFetch, row.SumTimerWait)),
+			utils.FormatPct(utils.Divide(row.SumTimerInsert, row.SumTimerWait)),
+			utils.FormatPct(utils.Divide(row.SumTimerUpdate, row.SumTimerWait)),
+			utils.FormatPct(utils.Divide(row.SumTimerDelete, row.SumTimerWait)),
+			name)
+	}
+)
+
+// Presenter presents a TableIo struct collected by index.
+type Presenter struct {
+	*presenter.BasePresenter[tableio.Row, *tableio.TableIo]
+}
+
+// NewIndexUsage creates a presenter for the table I/O of each index using the provided model.
+func NewIndexUsage(model *tableio.TableIo) *Presenter {
+	bp := presenter.NewBasePresenter(
+		model,
+		"Index I/O Latency (table_io_waits_summary_by_index_usage)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		presenter.TableIOColumns,
+		func(r tableio.Row) string { return r.Name },
+	)
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %6s %10s|%6s %6s|%6s %6s %6s %6s|%s",
+		"Latency", "%", "Ops", "Read", "Write", "Fetch", "Insert", "Update", "Delete", "Index Name")
+}
@@ -0,0 +1,84 @@
+// Package lockwaits holds the routines which manage the InnoDB row lock waits.
+package lockwaits
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/lockwaits"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(lockwaits.Row) uint64) func(a, b lockwaits.Row) int {
+	return presenter.ByValue(value, func(r lockwaits.Row) string { return r.Name() })
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[lockwaits.Row]{
+		{Heading: "Wait Age", Compare: byValue(func(r lockwaits.Row) uint64 { return r.WaitAge })},
+		{Heading: "Waiting", Compare: byValue(func(r lockwaits.Row) uint64 { return r.WaitingID })},
+		{Heading: "Blocking", Compare: byValue(func(r lockwaits.Row) uint64 { return r.BlockingID })},
+		{Heading: "Table (Index)", Compare: presenter.ByName(func(r lockwaits.Row) string { return r.Name() })},
+	}
+
+	defaultHasData = func(r lockwaits.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, _ lockwaits.Row) string {
+		var waiting, blocking string
+		if row.HasData() {
+			waiting = fmt.Sprint(row.WaitingID)
+			blocking = fmt.Sprint(row.BlockingID)
+		}
+		return fmt.Sprintf("%10s %8s %8s %-13.13s %-13.13s %-40.40s|%s",
+			utils.FormatTime(row.WaitAge),
+			waiting,
+			blocking,
+			row.WaitingLockMode,
+			row.BlockingLockMode,
+			row.Name(),
+			row.BlockingQuery)
+	}
+
+	defaultColumns = []presenter.Column[lockwaits.Row]{
+		{Name: "table", Value: func(r lockwaits.Row) any { return r.Table }},
+		{Name: "index", Value: func(r lockwaits.Row) any { return r.Index }},
+		{Name: "lock_type", Value: func(r lockwaits.Row) any { return r.LockType }},
+		{Name: "waiting_lock_mode", Value: func(r lockwaits.Row) any { return r.WaitingLockMode }},
+		{Name: "blocking_lock_mode", Value: func(r lockwaits.Row) any { return r.BlockingLockMode }},
+		{Name: "waiting_id", Value: func(r lockwaits.Row) any { return r.WaitingID }},
+		{Name: "waiting_query", Value: func(r lockwaits.Row) any { return r.WaitingQuery }},
+		{Name: "blocking_id", Value: func(r lockwaits.Row) any { return r.BlockingID }},
+		{Name: "blocking_query", Value: func(r lockwaits.Row) any { return r.BlockingQuery }},
+		{Name: "wait_age", Value: func(r lockwaits.Row) any { return r.WaitAge }},
+	}
+)
+
+// Presenter presents a LockWaits struct.
+type Presenter struct {
+	*presenter.BasePresenter[lockwaits.Row, *lockwaits.LockWaits]
+}
+
+// NewLockWaits creates a presenter for lockwaits.
+func NewLockWaits(cfg model.Config, db *sql.DB) *Presenter {
+	lw := lockwaits.NewLockWaits(cfg, db)
+	bp := presenter.NewBasePresenter(
+		lw,
+		"InnoDB Row Lock Waits (data_lock_waits)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r lockwaits.Row) string { return r.Name() },
+	)
+	bp.SetRowKey(func(r lockwaits.Row) string { return r.Key() })
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %8s %8s %-13s %-13s %-40s|%s",
+		"Wait Age", "Waiting", "Blocking", "Wait Mode", "Block Mode", "Table (Index)", "Blocking Statement")
+}
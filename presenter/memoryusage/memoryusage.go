@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/sjmudd/ps-top/model"
 	"github.com/sjmudd/ps-top/model/memoryusage"
@@ -12,33 +13,37 @@ import (
 	"github.com/sjmudd/ps-top/utils"
 )
 
+const (
+	ownerDescription = "Memory by Owner (memory_summary_by_*_by_event_name)"
+	topEvents        = 10                // number of memory events shown in the detail of an owner
+	topEventsFormat  = "%-36s %14s %14s" // the same widths as the rest of the detail
+)
+
 // Presenter presents a MemoryUsage struct and implements the Tabler interface
 // via embedded BasePresenter.
 type Presenter struct {
 	*presenter.BasePresenter[memoryusage.Row, *memoryusage.MemoryUsage]
+	nameHeading string // "Memory Area" or "Owner"
 }
 
 // NewMemoryUsage creates a presenter for MemoryUsage.
 func NewMemoryUsage(cfg model.Config, db *sql.DB) *Presenter {
-	mu := memoryusage.NewMemoryUsage(cfg, db)
-
-	// Sort by CurrentBytesUsed descending, then Name ascending.
-	defaultSort := func(rows []memoryusage.Row) {
-		slices.SortFunc(rows, func(a, b memoryusage.Row) int {
-			if a.CurrentBytesUsed > b.CurrentBytesUsed {
-				return -1
-			}
-			if a.CurrentBytesUsed < b.CurrentBytesUsed {
-				return 1
-			}
-			if a.Name < b.Name {
-				return -1
-			}
-			if a.Name > b.Name {
-				return 1
-			}
-			return 0
-		})
+	return newPresenter(
+		memoryusage.NewMemoryUsage(cfg, db),
+		"Memory Usage (memory_summary_global_by_event_name)",
+		"Memory Area")
+}
+
+func newPresenter(mu *memoryusage.MemoryUsage, description, nameHeading string) *Presenter {
+	// Sort by CurrentBytesUsed descending, then Name ascending, by default.
+	name := func(r memoryusage.Row) string { return r.Name }
+	sortKeys := []presenter.SortKey[memoryusage.Row]{
+		{Heading: "CurBytes", Compare: presenter.ByValue(func(r memoryusage.Row) int64 { return r.CurrentBytesUsed }, name)},
+		{Heading: "High Bytes", Compare: presenter.ByValue(func(r memoryusage.Row) int64 { return r.HighBytesUsed }, name)},
+		{Heading: "MemOps", Compare: presenter.ByValue(func(r memoryusage.Row) int64 { return r.TotalMemoryOps }, name)},
+		{Heading: "CurAlloc", Compare: presenter.ByValue(func(r memoryusage.Row) int64 { return r.CurrentCountUsed }, name)},
+		{Heading: "HiAlloc", Compare: presenter.ByValue(func(r memoryusage.Row) int64 { return r.HighCountUsed }, name)},
+		{Heading: nameHeading, Compare: presenter.ByName(name)},
 	}
 
 	// Count rows with meaningful data.
@@ -64,17 +69,88 @@ func NewMemoryUsage(cfg model.Config, db *sql.DB) *Presenter {
 			name)
 	}
 
+	// Raw data columns used when exporting rows.
+	columns := []presenter.Column[memoryusage.Row]{
+		{Name: "name", Value: func(r memoryusage.Row) any { return r.Name }},
+		{Name: "current_count_used", Value: func(r memoryusage.Row) any { return r.CurrentCountUsed }},
+		{Name: "high_count_used", Value: func(r memoryusage.Row) any { return r.HighCountUsed }},
+		{Name: "total_memory_ops", Value: func(r memoryusage.Row) any { return r.TotalMemoryOps }},
+		{Name: "current_bytes_used", Value: func(r memoryusage.Row) any { return r.CurrentBytesUsed }},
+		{Name: "high_bytes_used", Value: func(r memoryusage.Row) any { return r.HighBytesUsed }},
+		{Name: "total_bytes_managed", Value: func(r memoryusage.Row) any { return r.TotalBytesManaged }},
+	}
+
 	bp := presenter.NewBasePresenter(mu,
-		"Memory Usage (memory_summary_global_by_event_name)",
-		defaultSort,
+		description,
+		sortKeys,
 		hasData,
 		contentFn,
+		columns,
+		name,
 	)
-	return &Presenter{BasePresenter: bp}
+	return &Presenter{BasePresenter: bp, nameHeading: nameHeading}
 }
 
 // Headings returns the headings for a table.
 func (p *Presenter) Headings() string {
-	return "CurBytes         %  High Bytes|MemOps          %|CurAlloc       %   HiAlloc|Memory Area"
+	return "CurBytes         %  High Bytes|MemOps          %|CurAlloc       %   HiAlloc|" + p.nameHeading
 	//      1234567890  100.0%  1234567890|123456789  100.0%|12345678  100.0%  12345678|Some memory name
 }
+
+// OwnerPresenter presents the memory used by each user, host, account or
+// thread. The rows can be grouped by each of these in turn and the detail
+// of a row includes the memory events using the most memory.
+type OwnerPresenter struct {
+	*Presenter
+}
+
+// NewMemoryByOwner creates a presenter for the memory used by each owner.
+func NewMemoryByOwner(cfg model.Config, db *sql.DB) *OwnerPresenter {
+	return &OwnerPresenter{
+		Presenter: newPresenter(
+			memoryusage.NewMemoryByOwner(cfg, db),
+			ownerDescription,
+			"Owner"),
+	}
+}
+
+// NextGrouping changes to showing the memory of the next type of owner:
+// user, host, account or thread. It is shown from the next collection as
+// the data comes from a different table, until then the description
+// shows the change.
+func (p *OwnerPresenter) NextGrouping() {
+	p.GetModel().NextGrouping()
+}
+
+// Description returns the description including the type of owner, and
+// the type of owner shown from the next collection if it has changed.
+func (p *OwnerPresenter) Description() string {
+	description := p.Presenter.Description() + ", by " + p.GetModel().Grouping()
+	if next := p.GetModel().NextGroupingName(); next != "" {
+		description += " (by " + next + " from the next collection)"
+	}
+	return description
+}
+
+// Detail returns lines describing the memory used by the named owner
+// followed by the memory events of the owner using the most memory.
+// nil is returned if there is no such owner.
+func (p *OwnerPresenter) Detail(name string) []string {
+	// the raw data is collected by event so the owner's row is in the results
+	results := p.GetModel().GetResults()
+	i := slices.IndexFunc(results, func(r memoryusage.Row) bool { return r.Name == name })
+	if i < 0 {
+		return nil
+	}
+	lines := presenter.Detail(ownerDescription, results[i], results[i], false)
+
+	lines = append(lines, "", fmt.Sprintf(topEventsFormat, fmt.Sprintf("Top %d memory events", topEvents), "CurBytes", "High Bytes"))
+	for _, event := range p.GetModel().TopEvents(name, topEvents) {
+		lines = append(lines, fmt.Sprintf(topEventsFormat,
+			strings.TrimPrefix(event.Name, "memory/"),
+			utils.SignedFormatAmount(event.CurrentBytesUsed),
+			utils.SignedFormatAmount(event.HighBytesUsed)))
+	}
+
+	return lines
+}
@@ -0,0 +1,58 @@
+package presenter
+
+import (
+	"slices"
+)
+
+// MergedRow is a formatted row taken from one of several merged presenters.
+type MergedRow struct {
+	Source  int    // index of the presenter the row came from
+	Content string // formatted row
+}
+
+// base returns the BasePresenter so that presenters which embed it can be
+// recognised when merging.
+func (bp *BasePresenter[T, M]) base() *BasePresenter[T, M] {
+	return bp
+}
+
+// MergeContent returns the rows shown by each of the given presenters sorted
+// together using this presenter's sort order. Rows with the same ordering
+// keep the order of the presenters provided. Each row is formatted using the
+// totals of the presenter it came from. Entries which are not presenters of
+// the same type are skipped.
+func (bp *BasePresenter[T, M]) MergeContent(presenters []any) []MergedRow {
+	type sourceRow struct {
+		source int
+		row    T
+		totals T
+	}
+
+	var rows []sourceRow
+	for i, p := range presenters {
+		other, ok := p.(interface{ base() *BasePresenter[T, M] })
+		if !ok {
+			continue
+		}
+		totals := other.base().model.GetTotals()
+		for _, row := range other.base().results() {
+			rows = append(rows, sourceRow{source: i, row: row, totals: totals})
+		}
+	}
+
+	if len(bp.sortKeys) > 0 {
+		compare := bp.sortKeys[bp.sortIndex].Compare
+		slices.SortStableFunc(rows, func(a, b sourceRow) int {
+			if bp.reverse {
+				return compare(b.row, a.row)
+			}
+			return compare(a.row, b.row)
+		})
+	}
+
+	merged := make([]MergedRow, 0, len(rows))
+	for _, r := range rows {
+		merged = append(merged, MergedRow{Source: r.source, Content: bp.contentFn(r.row, r.totals)})
+	}
+	return merged
+}
@@ -0,0 +1,81 @@
+// Package metadatalocks holds the routines which manage the metadata locks.
+package metadatalocks
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/metadatalocks"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(metadatalocks.Row) uint64) func(a, b metadatalocks.Row) int {
+	return presenter.ByValue(value, func(r metadatalocks.Row) string { return r.Name() })
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[metadatalocks.Row]{
+		{Heading: "Wait Age", Compare: byValue(func(r metadatalocks.Row) uint64 { return r.WaitAge })},
+		{Heading: "Pend", Compare: byValue(func(r metadatalocks.Row) uint64 { return r.Pending })},
+		{Heading: "Grnt", Compare: byValue(func(r metadatalocks.Row) uint64 { return r.Granted })},
+		{Heading: "ObjType", Compare: presenter.ByName(func(r metadatalocks.Row) string { return r.ObjectType })},
+		{Heading: "Object", Compare: presenter.ByName(func(r metadatalocks.Row) string { return r.Name() })},
+	}
+
+	defaultHasData = func(r metadatalocks.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, _ metadatalocks.Row) string {
+		return fmt.Sprintf("%10s|%4s %-16.16s %-10.10s|%4s %-16.16s %-10.10s|%-10.10s %s",
+			utils.FormatTime(row.WaitAge),
+			utils.FormatCounterU(row.Pending, 4),
+			row.PendingTypes,
+			row.Waiters,
+			utils.FormatCounterU(row.Granted, 4),
+			row.GrantedTypes,
+			row.Owners,
+			row.ObjectType,
+			row.Object)
+	}
+
+	defaultColumns = []presenter.Column[metadatalocks.Row]{
+		{Name: "object_type", Value: func(r metadatalocks.Row) any { return r.ObjectType }},
+		{Name: "object", Value: func(r metadatalocks.Row) any { return r.Object }},
+		{Name: "pending", Value: func(r metadatalocks.Row) any { return r.Pending }},
+		{Name: "pending_types", Value: func(r metadatalocks.Row) any { return r.PendingTypes }},
+		{Name: "waiters", Value: func(r metadatalocks.Row) any { return r.Waiters }},
+		{Name: "wait_age", Value: func(r metadatalocks.Row) any { return r.WaitAge }},
+		{Name: "granted", Value: func(r metadatalocks.Row) any { return r.Granted }},
+		{Name: "granted_types", Value: func(r metadatalocks.Row) any { return r.GrantedTypes }},
+		{Name: "owners", Value: func(r metadatalocks.Row) any { return r.Owners }},
+	}
+)
+
+// Presenter presents a MetadataLocks struct.
+type Presenter struct {
+	*presenter.BasePresenter[metadatalocks.Row, *metadatalocks.MetadataLocks]
+}
+
+// NewMetadataLocks creates a presenter for metadatalocks.
+func NewMetadataLocks(cfg model.Config, db *sql.DB) *Presenter {
+	ml := metadatalocks.NewMetadataLocks(cfg, db)
+	bp := presenter.NewBasePresenter(
+		ml,
+		"Metadata Locks by Object (metadata_locks)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r metadatalocks.Row) string { return r.Name() },
+	)
+	bp.SetRowKey(func(r metadatalocks.Row) string { return r.Key() })
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s|%4s %-16s %-10s|%4s %-16s %-10s|%-10s %s",
+		"Wait Age", "Pend", "Pending Types", "Waiters", "Grnt", "Granted Types", "Owners", "ObjType", "Object")
+}
@@ -1,10 +1,12 @@
-// Package mutexlatency holds the routines which manage the server mutexes.
+// Package mutexlatency holds the routines which manage the server
+// synchronisation instruments: mutexes, rwlocks, prlocks, sxlocks and conds.
 package mutexlatency
 
 import (
 	"database/sql"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/sjmudd/ps-top/model"
 	"github.com/sjmudd/ps-top/model/mutexlatency"
@@ -12,29 +14,47 @@ import (
 	"github.com/sjmudd/ps-top/utils"
 )
 
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(mutexlatency.Row) uint64) func(a, b mutexlatency.Row) int {
+	return presenter.ByValue(value, func(r mutexlatency.Row) string { return r.Name })
+}
+
+const description = "Synchronisation Waits (events_waits_summary_global_by_event_name)"
+
 var (
-	defaultSort = func(rows []mutexlatency.Row) {
-		slices.SortFunc(rows, func(a, b mutexlatency.Row) int {
-			return utils.SumTimerWaitNameOrdering(
-				utils.NewSumTimerWaitName(a.Name, a.SumTimerWait),
-				utils.NewSumTimerWaitName(b.Name, b.SumTimerWait),
-			)
-		})
+	defaultSortKeys = []presenter.SortKey[mutexlatency.Row]{
+		{Heading: "Latency", Compare: byValue(func(r mutexlatency.Row) uint64 { return r.SumTimerWait })},
+		{Heading: "Waits", Compare: byValue(func(r mutexlatency.Row) uint64 { return r.CountStar })},
+		{Heading: "Name", Compare: presenter.ByName(func(r mutexlatency.Row) string { return r.Name })},
 	}
 
 	defaultHasData = func(r mutexlatency.Row) bool { return r.SumTimerWait > 0 }
 
 	defaultContent = func(row, totals mutexlatency.Row) string {
-		name := row.Name
-		if row.CountStar == 0 && name != "Totals" {
-			name = ""
+		primitive, name := row.Type(), row.Name
+		if name == "Totals" {
+			primitive = ""
+		} else {
+			// the type is shown in its own column
+			name = strings.TrimPrefix(name, primitive+"/")
+		}
+		if row.CountStar == 0 && row.Name != "Totals" {
+			primitive, name = "", ""
 		}
-		return fmt.Sprintf("%10s %8s %8s|%s",
+		return fmt.Sprintf("%10s %8s %8s|%-6s|%s",
 			utils.FormatTime(row.SumTimerWait),
 			utils.FormatAmount(row.CountStar),
 			utils.FormatPct(utils.Divide(row.SumTimerWait, totals.SumTimerWait)),
+			primitive,
 			name)
 	}
+
+	defaultColumns = []presenter.Column[mutexlatency.Row]{
+		{Name: "name", Value: func(r mutexlatency.Row) any { return r.Name }},
+		{Name: "type", Value: func(r mutexlatency.Row) any { return r.Type() }},
+		{Name: "sum_timer_wait", Value: func(r mutexlatency.Row) any { return r.SumTimerWait }},
+		{Name: "count_star", Value: func(r mutexlatency.Row) any { return r.CountStar }},
+	}
 )
 
 // Presenter presents a MutexLatency struct.
@@ -47,15 +67,51 @@ func NewMutexLatency(cfg model.Config, db *sql.DB) *Presenter {
 	ml := mutexlatency.NewMutexLatency(cfg, db)
 	bp := presenter.NewBasePresenter(
 		ml,
-		"Mutex Latency (events_waits_summary_global_by_event_name)",
-		defaultSort,
+		description,
+		defaultSortKeys,
 		defaultHasData,
 		defaultContent,
+		defaultColumns,
+		func(r mutexlatency.Row) string { return r.Name },
 	)
 	return &Presenter{BasePresenter: bp}
 }
 
+// NextGrouping groups the rows in the next way: by instrument, by type of
+// primitive or by subsystem.
+func (p *Presenter) NextGrouping() {
+	p.GetModel().NextGrouping()
+	p.Sort()
+}
+
+// Detail returns lines describing the named row, which is grouped in the
+// same way as the rows shown. nil is returned if there is no such row.
+func (p *Presenter) Detail(name string) []string {
+	ml := p.GetModel()
+	byName := func(r mutexlatency.Row) bool { return r.Name == name }
+
+	last, _ := ml.Snapshot()
+	rows := ml.Group(last)
+	i := slices.IndexFunc(rows, byName)
+	if i < 0 {
+		return nil
+	}
+	var baseline mutexlatency.Row
+	first, _ := ml.Baseline()
+	grouped := ml.Group(first)
+	if j := slices.IndexFunc(grouped, byName); j >= 0 {
+		baseline = grouped[j]
+	}
+
+	return presenter.Detail(description, rows[i], baseline, ml.HaveRelativeStats())
+}
+
+// Description returns the description including how the rows are grouped.
+func (p *Presenter) Description() string {
+	return p.BasePresenter.Description() + ", by " + p.GetModel().Grouping()
+}
+
 // Headings returns the headings for a table.
 func (p *Presenter) Headings() string {
-	return fmt.Sprintf("%10s %8s %8s|%s", "Latency", "MtxCnt", "%", "Mutex Name")
+	return fmt.Sprintf("%10s %8s %8s|%-6s|%s", "Latency", "Waits", "%", "Type", "Name")
 }
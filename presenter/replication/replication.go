@@ -0,0 +1,75 @@
+// Package replication holds the routines which manage the replication status.
+package replication
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/replication"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(replication.Row) uint64) func(a, b replication.Row) int {
+	return presenter.ByValue(value, func(r replication.Row) string { return r.Name() })
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[replication.Row]{
+		{Heading: "Lag", Compare: byValue(func(r replication.Row) uint64 { return r.Lag })},
+		{Heading: "Apply Time", Compare: byValue(func(r replication.Row) uint64 { return r.ApplyTime })},
+		{Heading: "State", Compare: presenter.ByName(func(r replication.Row) string { return r.State + "\x00" + r.Name() })},
+		{Heading: "Channel Thread", Compare: presenter.ByName(func(r replication.Row) string { return r.Name() })},
+	}
+
+	defaultHasData = func(r replication.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, _ replication.Row) string {
+		return fmt.Sprintf("%10s %10s %-10.10s %-30.30s %-36.36s|%s",
+			utils.FormatTime(row.Lag),
+			utils.FormatTime(row.ApplyTime),
+			row.State,
+			row.ErrorMessage(),
+			row.Transactions,
+			row.Name())
+	}
+
+	defaultColumns = []presenter.Column[replication.Row]{
+		{Name: "channel_name", Value: func(r replication.Row) any { return r.Channel }},
+		{Name: "thread", Value: func(r replication.Row) any { return r.Thread }},
+		{Name: "service_state", Value: func(r replication.Row) any { return r.State }},
+		{Name: "last_error_number", Value: func(r replication.Row) any { return r.LastErrorNumber }},
+		{Name: "last_error_message", Value: func(r replication.Row) any { return r.LastError }},
+		{Name: "transactions", Value: func(r replication.Row) any { return r.Transactions }},
+		{Name: "apply_time", Value: func(r replication.Row) any { return r.ApplyTime }},
+		{Name: "lag", Value: func(r replication.Row) any { return r.Lag }},
+	}
+)
+
+// Presenter presents a Replication struct.
+type Presenter struct {
+	*presenter.BasePresenter[replication.Row, *replication.Replication]
+}
+
+// NewReplication creates a presenter for replication.
+func NewReplication(cfg model.Config, db *sql.DB) *Presenter {
+	r := replication.NewReplication(cfg, db)
+	bp := presenter.NewBasePresenter(
+		r,
+		"Replication Status by Channel and Thread (replication_*_status)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r replication.Row) string { return r.Name() },
+	)
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %10s %-10s %-30s %-36s|%s",
+		"Lag", "Apply Time", "State", "Last Error", "Transactions", "Channel Thread")
+}
@@ -0,0 +1,87 @@
+// Package runningstatements holds the routines which manage the statements currently running.
+package runningstatements
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/runningstatements"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(runningstatements.Row) uint64) func(a, b runningstatements.Row) int {
+	return presenter.ByValue(value, func(r runningstatements.Row) string { return r.Name() })
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[runningstatements.Row]{
+		{Heading: "Run Time", Compare: byValue(func(r runningstatements.Row) uint64 { return r.TimerWait })},
+		{Heading: "Lock Time", Compare: byValue(func(r runningstatements.Row) uint64 { return r.LockTime })},
+		{Heading: "RowsExam", Compare: byValue(func(r runningstatements.Row) uint64 { return r.RowsExamined })},
+		{Heading: "RowsSent", Compare: byValue(func(r runningstatements.Row) uint64 { return r.RowsSent })},
+		{Heading: "User", Compare: presenter.ByName(func(r runningstatements.Row) string { return r.User })},
+		{Heading: "Host", Compare: presenter.ByName(func(r runningstatements.Row) string { return r.Host })},
+		{Heading: "Schema", Compare: presenter.ByName(func(r runningstatements.Row) string { return r.Schema })},
+		{Heading: "Statement", Compare: presenter.ByName(func(r runningstatements.Row) string { return r.Name() })},
+	}
+
+	defaultHasData = func(r runningstatements.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, totals runningstatements.Row) string {
+		return fmt.Sprintf("%10s %6s %10s %8s %8s|%-12.12s %-16.16s %-12.12s|%s",
+			utils.FormatTime(row.TimerWait),
+			utils.FormatPct(utils.Divide(row.TimerWait, totals.TimerWait)),
+			utils.FormatTime(row.LockTime),
+			utils.FormatAmount(row.RowsExamined),
+			utils.FormatAmount(row.RowsSent),
+			row.User,
+			row.Host,
+			row.Schema,
+			row.Name())
+	}
+
+	defaultColumns = []presenter.Column[runningstatements.Row]{
+		{Name: "thread_id", Value: func(r runningstatements.Row) any { return r.ThreadID }},
+		{Name: "event_id", Value: func(r runningstatements.Row) any { return r.EventID }},
+		{Name: "processlist_id", Value: func(r runningstatements.Row) any { return r.ProcesslistID }},
+		{Name: "user", Value: func(r runningstatements.Row) any { return r.User }},
+		{Name: "host", Value: func(r runningstatements.Row) any { return r.Host }},
+		{Name: "current_schema", Value: func(r runningstatements.Row) any { return r.Schema }},
+		{Name: "event_name", Value: func(r runningstatements.Row) any { return r.EventName }},
+		{Name: "sql_text", Value: func(r runningstatements.Row) any { return r.Text }},
+		{Name: "timer_wait", Value: func(r runningstatements.Row) any { return r.TimerWait }},
+		{Name: "lock_time", Value: func(r runningstatements.Row) any { return r.LockTime }},
+		{Name: "rows_examined", Value: func(r runningstatements.Row) any { return r.RowsExamined }},
+		{Name: "rows_sent", Value: func(r runningstatements.Row) any { return r.RowsSent }},
+	}
+)
+
+// Presenter presents a RunningStatements struct.
+type Presenter struct {
+	*presenter.BasePresenter[runningstatements.Row, *runningstatements.RunningStatements]
+}
+
+// NewRunningStatements creates a presenter for runningstatements.
+func NewRunningStatements(cfg model.Config, db *sql.DB) *Presenter {
+	rs := runningstatements.NewRunningStatements(cfg, db)
+	bp := presenter.NewBasePresenter(
+		rs,
+		"Running Statements (events_statements_current)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r runningstatements.Row) string { return r.Name() },
+	)
+	bp.SetRowKey(func(r runningstatements.Row) string { return r.Key() })
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %6s %10s %8s %8s|%-12s %-16s %-12s|%s",
+		"Run Time", "%", "Lock Time", "RowsExam", "RowsSent", "User", "Host", "Schema", "Statement")
+}
@@ -0,0 +1,30 @@
+package presenter
+
+import (
+	"cmp"
+)
+
+// SortKey describes one way of sorting the rows of a view.
+// The first sort key of a view is the default ordering.
+type SortKey[T any] struct {
+	Heading string           // heading of the column sorted on (first match is highlighted), empty if there is no single column
+	Compare func(a, b T) int // ordering of two rows as used by slices.SortFunc
+}
+
+// ByValue returns an ordering with the largest values first and
+// rows with the same value ordered by name.
+func ByValue[T any, V cmp.Ordered](value func(T) V, name func(T) string) func(a, b T) int {
+	return func(a, b T) int {
+		if c := cmp.Compare(value(b), value(a)); c != 0 {
+			return c
+		}
+		return cmp.Compare(name(a), name(b))
+	}
+}
+
+// ByName returns an ordering of rows by name.
+func ByName[T any](name func(T) string) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(name(a), name(b))
+	}
+}
@@ -0,0 +1,105 @@
+// Package stageprogress holds the routines which manage the progress of the stages running now.
+package stageprogress
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/stageprogress"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// barWidth is the number of characters of a progress bar
+const barWidth = 20
+
+// progressBar returns a bar showing the progress of a row, empty if the
+// work is not estimated
+func progressBar(row stageprogress.Row) string {
+	if row.WorkEstimated == 0 {
+		return ""
+	}
+	done := int(row.Progress() * barWidth)
+	return strings.Repeat("#", done) + strings.Repeat(".", barWidth-done)
+}
+
+// processlistID returns the processlist id of a row as a string, empty if not known
+func processlistID(row stageprogress.Row) string {
+	if row.ProcesslistID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", row.ProcesslistID)
+}
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue[V uint64 | float64](value func(stageprogress.Row) V) func(a, b stageprogress.Row) int {
+	return presenter.ByValue(value, func(r stageprogress.Row) string { return r.Name() })
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[stageprogress.Row]{
+		{Heading: "Elapsed", Compare: byValue(func(r stageprogress.Row) uint64 { return r.TimerWait })},
+		{Heading: "ETA", Compare: byValue(func(r stageprogress.Row) uint64 { return r.Remaining() })},
+		{Heading: "Done", Compare: byValue(func(r stageprogress.Row) float64 { return r.Progress() })},
+		{Heading: "User", Compare: presenter.ByName(func(r stageprogress.Row) string { return r.User })},
+		{Heading: "Stage", Compare: presenter.ByName(func(r stageprogress.Row) string { return r.Name() })},
+	}
+
+	defaultHasData = func(r stageprogress.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, _ stageprogress.Row) string {
+		return fmt.Sprintf("%10s %10s %6s|%-20s|%8s %-12.12s %-12.12s|%-40.40s|%s",
+			utils.FormatTime(row.TimerWait),
+			utils.FormatTime(row.Remaining()),
+			utils.FormatPct(row.Progress()),
+			progressBar(row),
+			processlistID(row),
+			row.User,
+			row.Schema,
+			row.Name(),
+			row.Text)
+	}
+
+	defaultColumns = []presenter.Column[stageprogress.Row]{
+		{Name: "thread_id", Value: func(r stageprogress.Row) any { return r.ThreadID }},
+		{Name: "processlist_id", Value: func(r stageprogress.Row) any { return r.ProcesslistID }},
+		{Name: "user", Value: func(r stageprogress.Row) any { return r.User }},
+		{Name: "host", Value: func(r stageprogress.Row) any { return r.Host }},
+		{Name: "schema", Value: func(r stageprogress.Row) any { return r.Schema }},
+		{Name: "event_name", Value: func(r stageprogress.Row) any { return r.EventName }},
+		{Name: "timer_wait", Value: func(r stageprogress.Row) any { return r.TimerWait }},
+		{Name: "work_completed", Value: func(r stageprogress.Row) any { return r.WorkCompleted }},
+		{Name: "work_estimated", Value: func(r stageprogress.Row) any { return r.WorkEstimated }},
+		{Name: "remaining_picoseconds", Value: func(r stageprogress.Row) any { return r.Remaining() }},
+		{Name: "sql_text", Value: func(r stageprogress.Row) any { return r.Text }},
+	}
+)
+
+// Presenter presents a StageProgress struct.
+type Presenter struct {
+	*presenter.BasePresenter[stageprogress.Row, *stageprogress.StageProgress]
+}
+
+// NewStageProgress creates a presenter for stageprogress.
+func NewStageProgress(cfg model.Config, db *sql.DB) *Presenter {
+	sp := stageprogress.NewStageProgress(cfg, db)
+	bp := presenter.NewBasePresenter(
+		sp,
+		"Stage Progress (events_stages_current)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r stageprogress.Row) string { return r.Name() },
+	)
+	bp.SetRowKey(func(r stageprogress.Row) string { return r.Key() })
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %10s %6s|%-20s|%8s %-12s %-12s|%-40s|%s",
+		"Elapsed", "ETA", "Done", "Progress", "Proc Id", "User", "Schema", "Stage", "Statement")
+}
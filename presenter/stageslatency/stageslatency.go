@@ -4,7 +4,6 @@ package stageslatency
 import (
 	"database/sql"
 	"fmt"
-	"slices"
 
 	"github.com/sjmudd/ps-top/model"
 	"github.com/sjmudd/ps-top/model/stageslatency"
@@ -12,14 +11,16 @@ import (
 	"github.com/sjmudd/ps-top/utils"
 )
 
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(stageslatency.Row) uint64) func(a, b stageslatency.Row) int {
+	return presenter.ByValue(value, func(r stageslatency.Row) string { return r.Name })
+}
+
 var (
-	defaultSort = func(rows []stageslatency.Row) {
-		slices.SortFunc(rows, func(a, b stageslatency.Row) int {
-			return utils.SumTimerWaitNameOrdering(
-				utils.NewSumTimerWaitName(a.Name, a.SumTimerWait),
-				utils.NewSumTimerWaitName(b.Name, b.SumTimerWait),
-			)
-		})
+	defaultSortKeys = []presenter.SortKey[stageslatency.Row]{
+		{Heading: "Latency", Compare: byValue(func(r stageslatency.Row) uint64 { return r.SumTimerWait })},
+		{Heading: "Counter", Compare: byValue(func(r stageslatency.Row) uint64 { return r.CountStar })},
+		{Heading: "Stage Name", Compare: presenter.ByName(func(r stageslatency.Row) string { return r.Name })},
 	}
 
 	defaultHasData = func(r stageslatency.Row) bool { return r.SumTimerWait > 0 }
@@ -35,6 +36,12 @@ var (
 			utils.FormatAmount(row.CountStar),
 			name)
 	}
+
+	defaultColumns = []presenter.Column[stageslatency.Row]{
+		{Name: "name", Value: func(r stageslatency.Row) any { return r.Name }},
+		{Name: "sum_timer_wait", Value: func(r stageslatency.Row) any { return r.SumTimerWait }},
+		{Name: "count_star", Value: func(r stageslatency.Row) any { return r.CountStar }},
+	}
 )
 
 // Presenter presents a StagesLatency struct.
@@ -48,9 +55,11 @@ func NewStagesLatency(cfg model.Config, db *sql.DB) *Presenter {
 	bp := presenter.NewBasePresenter(
 		sl,
 		"SQL Stage Latency (events_stages_summary_global_by_event_name)",
-		defaultSort,
+		defaultSortKeys,
 		defaultHasData,
 		defaultContent,
+		defaultColumns,
+		func(r stageslatency.Row) string { return r.Name },
 	)
 	return &Presenter{BasePresenter: bp}
 }
@@ -0,0 +1,100 @@
+// Package statementdigest holds the routines which manage the statistics of normalised statements.
+package statementdigest
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/statementdigest"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(statementdigest.Row) uint64) func(a, b statementdigest.Row) int {
+	return presenter.ByValue(value, func(r statementdigest.Row) string { return r.Name() })
+}
+
+// avgTimerWait returns the average latency of the statements in a row
+func avgTimerWait(r statementdigest.Row) uint64 {
+	if r.CountStar == 0 {
+		return 0
+	}
+	return r.SumTimerWait / r.CountStar
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[statementdigest.Row]{
+		{Heading: "Latency", Compare: byValue(func(r statementdigest.Row) uint64 { return r.SumTimerWait })},
+		{Heading: "Avg Lat", Compare: byValue(avgTimerWait)},
+		{Heading: "Max Lat", Compare: byValue(func(r statementdigest.Row) uint64 { return r.MaxTimerWait })},
+		{Heading: "Execs", Compare: byValue(func(r statementdigest.Row) uint64 { return r.CountStar })},
+		{Heading: "RowsExam", Compare: byValue(func(r statementdigest.Row) uint64 { return r.SumRowsExamined })},
+		{Heading: "RowsSent", Compare: byValue(func(r statementdigest.Row) uint64 { return r.SumRowsSent })},
+		{Heading: "TmpDisk", Compare: byValue(func(r statementdigest.Row) uint64 { return r.SumCreatedTmpDiskTables })},
+		{Heading: "NoIndex", Compare: byValue(func(r statementdigest.Row) uint64 { return r.SumNoIndexUsed })},
+		{Heading: "Statement", Compare: presenter.ByName(func(r statementdigest.Row) string { return r.Name() })},
+	}
+
+	defaultHasData = func(r statementdigest.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, totals statementdigest.Row) string {
+		name := row.Name()
+		if row.CountStar == 0 && name != "Totals" {
+			name = ""
+		}
+		return fmt.Sprintf("%10s %6s %10s %10s %8s %8s %8s %8s %8s|%s",
+			utils.FormatTime(row.SumTimerWait),
+			utils.FormatPct(utils.Divide(row.SumTimerWait, totals.SumTimerWait)),
+			utils.FormatTime(avgTimerWait(row)),
+			utils.FormatTime(row.MaxTimerWait),
+			utils.FormatAmount(row.CountStar),
+			utils.FormatAmount(row.SumRowsExamined),
+			utils.FormatAmount(row.SumRowsSent),
+			utils.FormatAmount(row.SumCreatedTmpDiskTables),
+			utils.FormatAmount(row.SumNoIndexUsed),
+			name)
+	}
+
+	defaultColumns = []presenter.Column[statementdigest.Row]{
+		{Name: "schema_name", Value: func(r statementdigest.Row) any { return r.Schema }},
+		{Name: "digest", Value: func(r statementdigest.Row) any { return r.Digest }},
+		{Name: "digest_text", Value: func(r statementdigest.Row) any { return r.Text }},
+		{Name: "count_star", Value: func(r statementdigest.Row) any { return r.CountStar }},
+		{Name: "sum_timer_wait", Value: func(r statementdigest.Row) any { return r.SumTimerWait }},
+		{Name: "max_timer_wait", Value: func(r statementdigest.Row) any { return r.MaxTimerWait }},
+		{Name: "sum_rows_examined", Value: func(r statementdigest.Row) any { return r.SumRowsExamined }},
+		{Name: "sum_rows_sent", Value: func(r statementdigest.Row) any { return r.SumRowsSent }},
+		{Name: "sum_created_tmp_tables", Value: func(r statementdigest.Row) any { return r.SumCreatedTmpTables }},
+		{Name: "sum_created_tmp_disk_tables", Value: func(r statementdigest.Row) any { return r.SumCreatedTmpDiskTables }},
+		{Name: "sum_no_index_used", Value: func(r statementdigest.Row) any { return r.SumNoIndexUsed }},
+		{Name: "sum_no_good_index_used", Value: func(r statementdigest.Row) any { return r.SumNoGoodIndexUsed }},
+	}
+)
+
+// Presenter presents a StatementDigest struct.
+type Presenter struct {
+	*presenter.BasePresenter[statementdigest.Row, *statementdigest.StatementDigest]
+}
+
+// NewStatementDigest creates a presenter for statementdigest.
+func NewStatementDigest(cfg model.Config, db *sql.DB) *Presenter {
+	sd := statementdigest.NewStatementDigest(cfg, db)
+	bp := presenter.NewBasePresenter(
+		sd,
+		"Statement Digest Latency (events_statements_summary_by_digest)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r statementdigest.Row) string { return r.Name() },
+	)
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %6s %10s %10s %8s %8s %8s %8s %8s|%s",
+		"Latency", "%", "Avg Lat", "Max Lat", "Execs", "RowsExam", "RowsSent", "TmpDisk", "NoIndex", "Statement")
+}
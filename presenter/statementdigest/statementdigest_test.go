@@ -0,0 +1,69 @@
+package statementdigest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/statementdigest"
+	"github.com/sjmudd/ps-top/presenter"
+)
+
+// newStatementDigest creates a Presenter for testing with the given rows.
+func newStatementDigest(rows statementdigest.Rows, totals statementdigest.Row) *Presenter {
+	process := func(last, _ statementdigest.Rows) (statementdigest.Rows, statementdigest.Row) {
+		// Not used because we set Results manually.
+		return last, statementdigest.Row{}
+	}
+	bc := model.NewBaseCollector[statementdigest.Row, statementdigest.Rows](nil, nil, process)
+	bc.Results = rows
+	bc.Totals = totals
+
+	bp := presenter.NewBasePresenter(
+		&statementdigest.StatementDigest{BaseCollector: bc},
+		"Statement Digest Latency (events_statements_summary_by_digest)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r statementdigest.Row) string { return r.Name() },
+	)
+	return &Presenter{BasePresenter: bp}
+}
+
+// TestRowContent checks the average latency and statement shown for each row.
+func TestRowContent(t *testing.T) {
+	rows := statementdigest.Rows{
+		{Schema: "db", Digest: "d1", Text: "SELECT * FROM `t1`", SumTimerWait: 4000000, CountStar: 4},
+		{Digest: "d2", Text: "COMMIT", SumTimerWait: 1000000, CountStar: 1},
+	}
+	p := newStatementDigest(rows, statementdigest.Row{Text: "Totals", SumTimerWait: 5000000, CountStar: 5})
+
+	lines := p.RowContent()
+	if len(lines) != 2 {
+		t.Fatalf("RowContent returned %d rows, want 2", len(lines))
+	}
+	if !strings.HasSuffix(lines[0], "|db: SELECT * FROM `t1`") || !strings.Contains(lines[0], "80.0%") || !strings.Contains(lines[0], "1.00 us") {
+		t.Errorf("unexpected first row: %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "|COMMIT") {
+		t.Errorf("unexpected second row: %q", lines[1])
+	}
+}
+
+// TestSortByAverage checks sorting on the average latency column.
+func TestSortByAverage(t *testing.T) {
+	rows := statementdigest.Rows{
+		{Digest: "d1", Text: "many", SumTimerWait: 4000, CountStar: 4},
+		{Digest: "d2", Text: "slow", SumTimerWait: 3000, CountStar: 1},
+	}
+	p := newStatementDigest(rows, statementdigest.Row{})
+
+	p.NextSortKey()
+	if got := p.SortHeading(); got != "Avg Lat" {
+		t.Fatalf("SortHeading() = %q, want %q", got, "Avg Lat")
+	}
+	if got := p.RowContent()[0]; !strings.HasSuffix(got, "|slow") {
+		t.Errorf("first row sorted by average latency = %q, want the slow statement", got)
+	}
+}
@@ -0,0 +1,115 @@
+// Package statementhistogram holds the routines which manage the latency histograms of normalised statements.
+package statementhistogram
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/statementhistogram"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// chartLimits are the highest latencies in picoseconds shown by each
+// character of a chart: 1us, 10us, 100us, 1ms, 10ms, 100ms, 1s, 10s and longer.
+var chartLimits = []uint64{1e6, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13}
+
+// chartLevels are the characters used for increasing numbers of statements
+var chartLevels = []rune(" ▁▂▃▄▅▆▇█")
+
+// chart returns a bar chart of the number of statements in each range of
+// chartLimits relative to the range with the most statements.
+func chart(row statementhistogram.Row) string {
+	counts := make([]uint64, len(chartLimits)+1)
+	for _, bucket := range row.Buckets {
+		i := 0
+		for i < len(chartLimits) && bucket.TimerHigh > chartLimits[i] {
+			i++
+		}
+		counts[i] += bucket.Count
+	}
+
+	highest := uint64(0)
+	for _, count := range counts {
+		highest = max(highest, count)
+	}
+
+	bars := make([]rune, len(counts))
+	for i, count := range counts {
+		level := 0
+		if count > 0 {
+			// round up so that any statements are seen
+			level = int((count*uint64(len(chartLevels)-1) + highest - 1) / highest)
+		}
+		bars[i] = chartLevels[level]
+	}
+	return string(bars)
+}
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(statementhistogram.Row) uint64) func(a, b statementhistogram.Row) int {
+	return presenter.ByValue(value, func(r statementhistogram.Row) string { return r.Name() })
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[statementhistogram.Row]{
+		{Heading: "P99", Compare: byValue(func(r statementhistogram.Row) uint64 { return r.Percentile(99) })},
+		{Heading: "P95", Compare: byValue(func(r statementhistogram.Row) uint64 { return r.Percentile(95) })},
+		{Heading: "P50", Compare: byValue(func(r statementhistogram.Row) uint64 { return r.Percentile(50) })},
+		{Heading: "Execs", Compare: byValue(func(r statementhistogram.Row) uint64 { return r.CountStar })},
+		{Heading: "Statement", Compare: presenter.ByName(func(r statementhistogram.Row) string { return r.Name() })},
+	}
+
+	defaultHasData = func(r statementhistogram.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, _ statementhistogram.Row) string {
+		name := row.Name()
+		if row.CountStar == 0 && name != "Totals" {
+			name = ""
+		}
+		return fmt.Sprintf("%8s %10s %10s %10s|%s|%s",
+			utils.FormatAmount(row.CountStar),
+			utils.FormatTime(row.Percentile(50)),
+			utils.FormatTime(row.Percentile(95)),
+			utils.FormatTime(row.Percentile(99)),
+			chart(row),
+			name)
+	}
+
+	defaultColumns = []presenter.Column[statementhistogram.Row]{
+		{Name: "schema_name", Value: func(r statementhistogram.Row) any { return r.Schema }},
+		{Name: "digest", Value: func(r statementhistogram.Row) any { return r.Digest }},
+		{Name: "digest_text", Value: func(r statementhistogram.Row) any { return r.Text }},
+		{Name: "count_star", Value: func(r statementhistogram.Row) any { return r.CountStar }},
+		{Name: "p50_timer_wait", Value: func(r statementhistogram.Row) any { return r.Percentile(50) }},
+		{Name: "p95_timer_wait", Value: func(r statementhistogram.Row) any { return r.Percentile(95) }},
+		{Name: "p99_timer_wait", Value: func(r statementhistogram.Row) any { return r.Percentile(99) }},
+	}
+)
+
+// Presenter presents a StatementHistogram struct.
+type Presenter struct {
+	*presenter.BasePresenter[statementhistogram.Row, *statementhistogram.StatementHistogram]
+}
+
+// NewStatementHistogram creates a presenter for statementhistogram.
+func NewStatementHistogram(cfg model.Config, db *sql.DB) *Presenter {
+	sh := statementhistogram.NewStatementHistogram(cfg, db)
+	bp := presenter.NewBasePresenter(
+		sh,
+		"Statement Latency Histogram (events_statements_histogram_by_digest)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r statementhistogram.Row) string { return r.Name() },
+	)
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%8s %10s %10s %10s|%-9s|%s",
+		"Execs", "P50", "P95", "P99", "1us..10s+", "Statement")
+}
@@ -0,0 +1,30 @@
+package statementhistogram
+
+import (
+	"testing"
+
+	"github.com/sjmudd/ps-top/model/statementhistogram"
+)
+
+func TestChart(t *testing.T) {
+	tests := []struct {
+		buckets  []statementhistogram.Bucket
+		expected string
+	}{
+		{nil, "         "},
+		{
+			[]statementhistogram.Bucket{
+				{TimerHigh: 500000, Count: 80},        // < 1us
+				{TimerHigh: 1000000, Count: 80},       // 1us
+				{TimerHigh: 5000000000, Count: 40},    // 10ms
+				{TimerHigh: 20000000000000, Count: 1}, // > 10s
+			},
+			"█   ▂   ▁",
+		},
+	}
+	for _, test := range tests {
+		if got := chart(statementhistogram.Row{Buckets: test.buckets}); got != test.expected {
+			t.Errorf("chart(%+v) = %q, want %q", test.buckets, got, test.expected)
+		}
+	}
+}
@@ -3,22 +3,28 @@ package tableiolatency
 
 import (
 	"fmt"
-	"slices"
 
 	"github.com/sjmudd/ps-top/model/tableio"
 	"github.com/sjmudd/ps-top/presenter"
 	"github.com/sjmudd/ps-top/utils"
 )
 
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(tableio.Row) uint64) func(a, b tableio.Row) int {
+	return presenter.ByValue(value, func(r tableio.Row) string { return r.Name })
+}
+
 // Default functions for BasePresenter, shared with tests.
 var (
-	defaultSort = func(rows []tableio.Row) {
-		slices.SortFunc(rows, func(a, b tableio.Row) int {
-			return utils.SumTimerWaitNameOrdering(
-				utils.NewSumTimerWaitName(a.Name, a.SumTimerWait),
-				utils.NewSumTimerWaitName(b.Name, b.SumTimerWait),
-			)
-		})
+	defaultSortKeys = []presenter.SortKey[tableio.Row]{
+		{Heading: "Latency", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerWait })},
+		{Heading: "Read", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerRead })},
+		{Heading: "Write", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerWrite })},
+		{Heading: "Fetch", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerFetch })},
+		{Heading: "Insert", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerInsert })},
+		{Heading: "Update", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerUpdate })},
+		{Heading: "Delete", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerDelete })},
+		{Heading: "Table Name", Compare: presenter.ByName(func(r tableio.Row) string { return r.Name })},
 	}
 
 	defaultHasData = func(r tableio.Row) bool { return r.HasData() }
@@ -52,9 +58,11 @@ func NewTableIoLatency(model *tableio.TableIo) *Presenter {
 	bp := presenter.NewBasePresenter(
 		model,
 		"Table I/O Latency (table_io_waits_summary_by_table)",
-		defaultSort,
+		defaultSortKeys,
 		defaultHasData,
 		defaultContent,
+		presenter.TableIOColumns,
+		func(r tableio.Row) string { return r.Name },
 	)
 	return &Presenter{BasePresenter: bp}
 }
@@ -27,9 +27,11 @@ func newTableIo(rows []tableio.Row, totals tableio.Row) *Presenter {
 	bp := presenter.NewBasePresenter(
 		tiol,
 		"Table I/O Latency (table_io_waits_summary_by_table)",
-		defaultSort,
+		defaultSortKeys,
 		defaultHasData,
 		defaultContent,
+		presenter.TableIOColumns,
+		func(r tableio.Row) string { return r.Name },
 	)
 	return &Presenter{BasePresenter: bp}
 }
@@ -112,3 +114,250 @@ func TestRowContentOperationPercentages(t *testing.T) {
 		}
 	}
 }
+
+// TestColumnValues checks the raw column values are not formatted.
+func TestColumnValues(t *testing.T) {
+	rows := []tableio.Row{{Name: "db.t", SumTimerWait: 1234567890123, CountStar: 42}}
+	w := newTableIo(rows, tableio.Row{})
+
+	names := w.ColumnNames()
+	values := w.ColumnValues()
+	if len(values) != 1 || len(values[0]) != len(names) {
+		t.Fatalf("ColumnValues() returned %v for columns %v", values, names)
+	}
+	for i, name := range names {
+		switch name {
+		case "name":
+			if values[0][i] != "db.t" {
+				t.Errorf("name: expected %q, got %q", "db.t", values[0][i])
+			}
+		case "sum_timer_wait":
+			if values[0][i] != "1234567890123" {
+				t.Errorf("sum_timer_wait: expected %q, got %q", "1234567890123", values[0][i])
+			}
+		case "count_star":
+			if values[0][i] != "42" {
+				t.Errorf("count_star: expected %q, got %q", "42", values[0][i])
+			}
+		}
+	}
+}
+
+// TestSortKeys checks changing the sort column and reversing the order.
+func TestSortKeys(t *testing.T) {
+	rows := []tableio.Row{
+		{Name: "db.a", CountStar: 1, SumTimerWait: 300, SumTimerFetch: 100},
+		{Name: "db.b", CountStar: 1, SumTimerWait: 200, SumTimerFetch: 200},
+		{Name: "db.c", CountStar: 1, SumTimerWait: 100, SumTimerFetch: 0},
+	}
+	w := newTableIo(rows, tableio.Row{SumTimerWait: 600})
+
+	if w.SortHeading() != "Latency" {
+		t.Errorf("expected default sort heading Latency, got %q", w.SortHeading())
+	}
+
+	// Latency -> Read -> Write -> Fetch
+	for i := 0; i < 3; i++ {
+		w.NextSortKey()
+	}
+	if w.SortHeading() != "Fetch" {
+		t.Fatalf("expected sort heading Fetch, got %q", w.SortHeading())
+	}
+	names := func() string {
+		var n []string
+		for _, r := range w.GetModel().GetResults() {
+			n = append(n, r.Name)
+		}
+		return strings.Join(n, ",")
+	}
+	if got := names(); got != "db.b,db.a,db.c" {
+		t.Errorf("sorted by Fetch: expected db.b,db.a,db.c, got %s", got)
+	}
+	if !strings.Contains(w.Description(), "sorted by Fetch") {
+		t.Errorf("Description missing sort column: %q", w.Description())
+	}
+
+	w.ReverseSort()
+	if got := names(); got != "db.c,db.a,db.b" {
+		t.Errorf("reverse sorted by Fetch: expected db.c,db.a,db.b, got %s", got)
+	}
+	if !w.SortReversed() || !strings.Contains(w.Description(), "(reversed)") {
+		t.Errorf("expected reversed sort, description: %q", w.Description())
+	}
+
+	// wraps around back to the default
+	for i := 0; i < 5; i++ {
+		w.NextSortKey()
+	}
+	if w.SortHeading() != "Latency" {
+		t.Errorf("expected sort keys to wrap around to Latency, got %q", w.SortHeading())
+	}
+}
+
+// TestSetFilter checks rows are filtered by name using a regular
+// expression or a plain substring if the pattern is not valid.
+func TestSetFilter(t *testing.T) {
+	rows := []tableio.Row{
+		{Name: "shop.orders", CountStar: 1, SumTimerWait: 300},
+		{Name: "shop.items", CountStar: 1, SumTimerWait: 200},
+		{Name: "blog.posts", CountStar: 1, SumTimerWait: 100},
+	}
+	w := newTableIo(rows, tableio.Row{SumTimerWait: 600})
+
+	tests := []struct {
+		pattern  string
+		expected int
+	}{
+		{"", 3},
+		{"shop", 2},
+		{"SHOP", 2},
+		{`^blog\.`, 1},
+		{"s$", 3},
+		{"orders(", 0}, // invalid regexp so used as a substring
+		{"nothing", 0},
+	}
+	for _, test := range tests {
+		w.SetFilter(test.pattern)
+		if got := len(w.RowContent()); got != test.expected {
+			t.Errorf("SetFilter(%q): expected %d rows, got %d", test.pattern, test.expected, got)
+		}
+		if w.Filter() != test.pattern {
+			t.Errorf("SetFilter(%q): Filter() returned %q", test.pattern, w.Filter())
+		}
+	}
+
+	w.SetFilter("shop")
+	if !strings.Contains(w.Description(), `filter: "shop"`) {
+		t.Errorf("Description missing filter: %q", w.Description())
+	}
+	if !strings.Contains(w.Description(), " 2 rows") {
+		t.Errorf("Description should count filtered rows: %q", w.Description())
+	}
+	if !strings.HasSuffix(w.TotalRowContent(), " (unfiltered)") {
+		t.Errorf("TotalRowContent should be marked as unfiltered: %q", w.TotalRowContent())
+	}
+
+	w.SetFilter("")
+	if strings.HasSuffix(w.TotalRowContent(), " (unfiltered)") {
+		t.Errorf("TotalRowContent without a filter should not be marked: %q", w.TotalRowContent())
+	}
+}
+
+// TestSelection checks the selected row follows its name when the rows
+// are sorted differently and moves are limited to the rows shown.
+func TestSelection(t *testing.T) {
+	rows := []tableio.Row{
+		{Name: "db.a", CountStar: 1, SumTimerWait: 300, SumTimerFetch: 100},
+		{Name: "db.b", CountStar: 1, SumTimerWait: 200, SumTimerFetch: 200},
+		{Name: "db.c", CountStar: 1, SumTimerWait: 100, SumTimerFetch: 300},
+	}
+	w := newTableIo(rows, tableio.Row{SumTimerWait: 600})
+
+	if w.SelectedRow() != -1 || w.SelectedName() != "" {
+		t.Fatalf("expected no selection initially, got %d (%q)", w.SelectedRow(), w.SelectedName())
+	}
+
+	w.MoveSelection(1)
+	w.MoveSelection(1)
+	if w.SelectedRow() != 1 || w.SelectedName() != "db.b" {
+		t.Errorf("expected db.b at row 1, got %q at row %d", w.SelectedName(), w.SelectedRow())
+	}
+
+	w.ReverseSort()
+	if w.SelectedName() != "db.b" || w.SelectedRow() != 1 {
+		t.Errorf("expected db.b to stay selected at row 1, got %q at row %d", w.SelectedName(), w.SelectedRow())
+	}
+	w.ReverseSort()
+
+	w.MoveSelection(10)
+	if w.SelectedName() != "db.c" {
+		t.Errorf("moving past the end should select the last row, got %q", w.SelectedName())
+	}
+	w.SelectFirst()
+	if w.SelectedName() != "db.a" {
+		t.Errorf("SelectFirst() should select db.a, got %q", w.SelectedName())
+	}
+	w.MoveSelection(-1)
+	if w.SelectedName() != "db.a" {
+		t.Errorf("moving before the start should keep the first row, got %q", w.SelectedName())
+	}
+	w.SelectLast()
+	if w.SelectedName() != "db.c" {
+		t.Errorf("SelectLast() should select db.c, got %q", w.SelectedName())
+	}
+
+	// hiding the selected row removes the selection
+	w.SetFilter("db.a")
+	if w.SelectedRow() != -1 || w.SelectedName() != "" {
+		t.Errorf("expected no selection when filtered out, got %q at row %d", w.SelectedName(), w.SelectedRow())
+	}
+}
+
+// TestDetail checks every counter of the selected row is shown with
+// absolute and relative values and average latencies.
+func TestDetail(t *testing.T) {
+	process := func(last, _ tableio.Rows) (tableio.Rows, tableio.Row) { return last, tableio.Row{} }
+	bc := model.NewBaseCollector[tableio.Row, tableio.Rows](nil, nil, process)
+	bc.First = tableio.Rows{{Name: "db.t", SumTimerWait: 1000000, CountStar: 10, SumTimerFetch: 1000000, CountFetch: 10}}
+	bc.Last = tableio.Rows{{Name: "db.t", SumTimerWait: 3000000, CountStar: 20, SumTimerFetch: 3000000, CountFetch: 20}}
+	w := &Presenter{BasePresenter: presenter.NewBasePresenter(
+		&tableio.TableIo{BaseCollector: bc},
+		"Table I/O Latency (table_io_waits_summary_by_table)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		presenter.TableIOColumns,
+		func(r tableio.Row) string { return r.Name },
+	)}
+
+	if lines := w.Detail("db.missing"); lines != nil {
+		t.Errorf("expected no detail for a missing row, got %q", lines)
+	}
+
+	lines := w.Detail("db.t")
+	all := strings.Join(lines, "\n")
+	if !strings.HasPrefix(lines[0], "Table I/O Latency") {
+		t.Errorf("expected title line, got %q", lines[0])
+	}
+	for _, expected := range []string{
+		"SumTimerWait", "SumTimerDelete", "CountStar", "CountDelete", // every counter
+		"3.00 us", "2.00 us", // absolute and relative SumTimerWait
+		"Average Fetch latency", "150.00 ns", "200.00 ns", // absolute and relative averages
+	} {
+		if !strings.Contains(all, expected) {
+			t.Errorf("detail missing %q:\n%s", expected, all)
+		}
+	}
+}
+
+// TestMergeContent verifies that rows from several presenters are sorted
+// together and formatted using their own totals.
+func TestMergeContent(t *testing.T) {
+	primary := newTableIo([]tableio.Row{
+		{Name: "db.a", CountStar: 1, SumTimerWait: 3000000},
+		{Name: "db.b", CountStar: 1, SumTimerWait: 1000000},
+	}, tableio.Row{SumTimerWait: 4000000})
+	replica := newTableIo([]tableio.Row{
+		{Name: "db.a", CountStar: 1, SumTimerWait: 2000000},
+	}, tableio.Row{SumTimerWait: 2000000})
+
+	merged := primary.MergeContent([]any{primary, nil, replica})
+
+	wantSources := []int{0, 2, 0}
+	if len(merged) != len(wantSources) {
+		t.Fatalf("MergeContent returned %d rows, want %d", len(merged), len(wantSources))
+	}
+	for i, want := range wantSources {
+		if merged[i].Source != want {
+			t.Errorf("row %d: source %d, want %d", i, merged[i].Source, want)
+		}
+	}
+	if !strings.Contains(merged[1].Content, "100.0%") {
+		t.Errorf("replica row not relative to its own totals: %q", merged[1].Content)
+	}
+
+	primary.ReverseSort()
+	if merged = primary.MergeContent([]any{primary, replica}); merged[0].Source != 0 || !strings.Contains(merged[0].Content, "db.b") {
+		t.Errorf("reversed merge starts with %+v, want db.b from source 0", merged[0])
+	}
+}
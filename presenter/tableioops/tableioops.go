@@ -3,31 +3,27 @@ package tableioops
 
 import (
 	"fmt"
-	"slices"
 
 	"github.com/sjmudd/ps-top/model/tableio"
 	"github.com/sjmudd/ps-top/presenter"
 	"github.com/sjmudd/ps-top/utils"
 )
 
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(tableio.Row) uint64) func(a, b tableio.Row) int {
+	return presenter.ByValue(value, func(r tableio.Row) string { return r.Name })
+}
+
 var (
-	// sort by CountStar (ops) descending, Name ascending.
-	defaultSort = func(rows []tableio.Row) {
-		slices.SortFunc(rows, func(a, b tableio.Row) int {
-			if a.CountStar > b.CountStar {
-				return -1
-			}
-			if a.CountStar < b.CountStar {
-				return 1
-			}
-			if a.Name < b.Name {
-				return -1
-			}
-			if a.Name > b.Name {
-				return 1
-			}
-			return 0
-		})
+	defaultSortKeys = []presenter.SortKey[tableio.Row]{
+		{Heading: "Ops", Compare: byValue(func(r tableio.Row) uint64 { return r.CountStar })},
+		{Heading: "Read", Compare: byValue(func(r tableio.Row) uint64 { return r.CountRead })},
+		{Heading: "Write", Compare: byValue(func(r tableio.Row) uint64 { return r.CountWrite })},
+		{Heading: "Fetch", Compare: byValue(func(r tableio.Row) uint64 { return r.CountFetch })},
+		{Heading: "Insert", Compare: byValue(func(r tableio.Row) uint64 { return r.CountInsert })},
+		{Heading: "Update", Compare: byValue(func(r tableio.Row) uint64 { return r.CountUpdate })},
+		{Heading: "Delete", Compare: byValue(func(r tableio.Row) uint64 { return r.CountDelete })},
+		{Heading: "Table Name", Compare: presenter.ByName(func(r tableio.Row) string { return r.Name })},
 	}
 
 	defaultHasData = func(r tableio.Row) bool { return r.HasData() }
@@ -62,9 +58,11 @@ func NewTableIoOps(model *tableio.TableIo) *Presenter {
 	bp := presenter.NewBasePresenter(
 		model,
 		"Table I/O Ops (table_io_waits_summary_by_table)",
-		defaultSort,
+		defaultSortKeys,
 		defaultHasData,
 		defaultContent,
+		presenter.TableIOColumns,
+		func(r tableio.Row) string { return r.Name },
 	)
 	return &Presenter{BasePresenter: bp}
 }
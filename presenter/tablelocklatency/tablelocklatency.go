@@ -4,22 +4,23 @@ package tablelocklatency
 import (
 	"database/sql"
 	"fmt"
-	"slices"
 
 	"github.com/sjmudd/ps-top/model"
 	"github.com/sjmudd/ps-top/model/tablelocks"
 	"github.com/sjmudd/ps-top/presenter"
-	"github.com/sjmudd/ps-top/utils"
 )
 
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(tablelocks.Row) uint64) func(a, b tablelocks.Row) int {
+	return presenter.ByValue(value, func(r tablelocks.Row) string { return r.Name })
+}
+
 var (
-	defaultSort = func(rows []tablelocks.Row) {
-		slices.SortFunc(rows, func(a, b tablelocks.Row) int {
-			return utils.SumTimerWaitNameOrdering(
-				utils.NewSumTimerWaitName(a.Name, a.SumTimerWait),
-				utils.NewSumTimerWaitName(b.Name, b.SumTimerWait),
-			)
-		})
+	defaultSortKeys = []presenter.SortKey[tablelocks.Row]{
+		{Heading: "Latency", Compare: byValue(func(r tablelocks.Row) uint64 { return r.SumTimerWait })},
+		{Heading: "Read", Compare: byValue(func(r tablelocks.Row) uint64 { return r.SumTimerRead })},
+		{Heading: "Write", Compare: byValue(func(r tablelocks.Row) uint64 { return r.SumTimerWrite })},
+		{Heading: "Table Name", Compare: presenter.ByName(func(r tablelocks.Row) string { return r.Name })},
 	}
 
 	// No hasData filter; count all rows.
@@ -65,6 +66,23 @@ var (
 			pct[11],
 			name)
 	}
+
+	defaultColumns = []presenter.Column[tablelocks.Row]{
+		{Name: "name", Value: func(r tablelocks.Row) any { return r.Name }},
+		{Name: "sum_timer_wait", Value: func(r tablelocks.Row) any { return r.SumTimerWait }},
+		{Name: "sum_timer_read", Value: func(r tablelocks.Row) any { return r.SumTimerRead }},
+		{Name: "sum_timer_write", Value: func(r tablelocks.Row) any { return r.SumTimerWrite }},
+		{Name: "sum_timer_read_with_shared_locks", Value: func(r tablelocks.Row) any { return r.SumTimerReadWithSharedLocks }},
+		{Name: "sum_timer_read_high_priority", Value: func(r tablelocks.Row) any { return r.SumTimerReadHighPriority }},
+		{Name: "sum_timer_read_no_insert", Value: func(r tablelocks.Row) any { return r.SumTimerReadNoInsert }},
+		{Name: "sum_timer_read_normal", Value: func(r tablelocks.Row) any { return r.SumTimerReadNormal }},
+		{Name: "sum_timer_read_external", Value: func(r tablelocks.Row) any { return r.SumTimerReadExternal }},
+		{Name: "sum_timer_write_allow_write", Value: func(r tablelocks.Row) any { return r.SumTimerWriteAllowWrite }},
+		{Name: "sum_timer_write_concurrent_insert", Value: func(r tablelocks.Row) any { return r.SumTimerWriteConcurrentInsert }},
+		{Name: "sum_timer_write_low_priority", Value: func(r tablelocks.Row) any { return r.SumTimerWriteLowPriority }},
+		{Name: "sum_timer_write_normal", Value: func(r tablelocks.Row) any { return r.SumTimerWriteNormal }},
+		{Name: "sum_timer_write_external", Value: func(r tablelocks.Row) any { return r.SumTimerWriteExternal }},
+	}
 )
 
 // Presenter presents a TableLocks struct.
@@ -78,9 +96,11 @@ func NewTableLockLatency(cfg model.Config, db *sql.DB) *Presenter {
 	bp := presenter.NewBasePresenter(
 		tl,
 		"Locks by Table Name (table_lock_waits_summary_by_table)",
-		defaultSort,
+		defaultSortKeys,
 		nil, // hasData: count all rows
 		defaultContent,
+		defaultColumns,
+		func(r tablelocks.Row) string { return r.Name },
 	)
 	return &Presenter{BasePresenter: bp}
 }
@@ -0,0 +1,93 @@
+// Package transactions holds the routines which manage the open transactions.
+package transactions
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model"
+	"github.com/sjmudd/ps-top/model/transactions"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(transactions.Row) uint64) func(a, b transactions.Row) int {
+	return presenter.ByValue(value, func(r transactions.Row) string { return r.Name() })
+}
+
+// processlistID returns the processlist id of a row as a string, empty if not known
+func processlistID(row transactions.Row) string {
+	if row.ProcesslistID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", row.ProcesslistID)
+}
+
+var (
+	defaultSortKeys = []presenter.SortKey[transactions.Row]{
+		{Heading: "Age", Compare: byValue(func(r transactions.Row) uint64 { return r.Age })},
+		{Heading: "RowsMod", Compare: byValue(func(r transactions.Row) uint64 { return r.RowsModified })},
+		{Heading: "RowsLock", Compare: byValue(func(r transactions.Row) uint64 { return r.RowsLocked })},
+		{Heading: "User", Compare: presenter.ByName(func(r transactions.Row) string { return r.User })},
+		{Heading: "Host", Compare: presenter.ByName(func(r transactions.Row) string { return r.Host })},
+		{Heading: "Last Statement", Compare: presenter.ByName(func(r transactions.Row) string { return r.Name() })},
+	}
+
+	defaultHasData = func(r transactions.Row) bool { return r.HasData() }
+
+	defaultContent = func(row, _ transactions.Row) string {
+		return fmt.Sprintf("%10s %8s %8s|%8s %-12.12s %-16.16s %-7.7s %-12.12s %-16.16s|%s",
+			utils.FormatTime(row.Age),
+			utils.FormatAmount(row.RowsModified),
+			utils.FormatAmount(row.RowsLocked),
+			processlistID(row),
+			row.User,
+			row.Host,
+			row.Command,
+			row.State,
+			row.IsolationLevel,
+			row.LastStatement)
+	}
+
+	defaultColumns = []presenter.Column[transactions.Row]{
+		{Name: "thread_id", Value: func(r transactions.Row) any { return r.ThreadID }},
+		{Name: "processlist_id", Value: func(r transactions.Row) any { return r.ProcesslistID }},
+		{Name: "user", Value: func(r transactions.Row) any { return r.User }},
+		{Name: "host", Value: func(r transactions.Row) any { return r.Host }},
+		{Name: "command", Value: func(r transactions.Row) any { return r.Command }},
+		{Name: "state", Value: func(r transactions.Row) any { return r.State }},
+		{Name: "isolation_level", Value: func(r transactions.Row) any { return r.IsolationLevel }},
+		{Name: "age_picoseconds", Value: func(r transactions.Row) any { return r.Age }},
+		{Name: "rows_modified", Value: func(r transactions.Row) any { return r.RowsModified }},
+		{Name: "rows_locked", Value: func(r transactions.Row) any { return r.RowsLocked }},
+		{Name: "last_statement", Value: func(r transactions.Row) any { return r.LastStatement }},
+	}
+)
+
+// Presenter presents a Transactions struct.
+type Presenter struct {
+	*presenter.BasePresenter[transactions.Row, *transactions.Transactions]
+}
+
+// NewTransactions creates a presenter for transactions.
+func NewTransactions(cfg model.Config, db *sql.DB) *Presenter {
+	t := transactions.NewTransactions(cfg, db)
+	bp := presenter.NewBasePresenter(
+		t,
+		"Open Transactions (events_transactions_current)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		defaultColumns,
+		func(r transactions.Row) string { return r.Name() },
+	)
+	bp.SetRowKey(func(r transactions.Row) string { return r.Key() })
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %8s %8s|%8s %-12s %-16s %-7s %-12s %-16s|%s",
+		"Age", "RowsMod", "RowsLock", "Proc Id", "User", "Host", "Command", "State", "Isolation", "Last Statement")
+}
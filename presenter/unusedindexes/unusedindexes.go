@@ -0,0 +1,66 @@
+// Package unusedindexes holds the routines which manage the indexes not read since the server started.
+package unusedindexes
+
+import (
+	"fmt"
+
+	"github.com/sjmudd/ps-top/model/tableio"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/utils"
+)
+
+// byValue orders rows by the given value, largest first, and then by name.
+func byValue(value func(tableio.Row) uint64) func(a, b tableio.Row) int {
+	return presenter.ByValue(value, func(r tableio.Row) string { return r.Name })
+}
+
+var (
+	// the cost of an unused index is the time spent maintaining it
+	defaultSortKeys = []presenter.SortKey[tableio.Row]{
+		{Heading: "Write Lat", Compare: byValue(func(r tableio.Row) uint64 { return r.SumTimerWrite })},
+		{Heading: "Writes", Compare: byValue(func(r tableio.Row) uint64 { return r.CountWrite })},
+		{Heading: "Inserts", Compare: byValue(func(r tableio.Row) uint64 { return r.CountInsert })},
+		{Heading: "Updates", Compare: byValue(func(r tableio.Row) uint64 { return r.CountUpdate })},
+		{Heading: "Deletes", Compare: byValue(func(r tableio.Row) uint64 { return r.CountDelete })},
+		{Heading: "Index Name", Compare: presenter.ByName(func(r tableio.Row) string { return r.Name })},
+	}
+
+	// an index never used at all has no latency so all rows are shown
+	defaultHasData = func(r tableio.Row) bool { return r.Name != "" }
+
+	defaultContent = func(row, totals tableio.Row) string {
+		return fmt.Sprintf("%10s %6s %10s|%10s %10s %10s|%s",
+			utils.FormatTime(row.SumTimerWrite),
+			utils.FormatPct(utils.Divide(row.SumTimerWrite, totals.SumTimerWrite)),
+			utils.FormatCounterU(row.CountWrite, 10),
+			utils.FormatCounterU(row.CountInsert, 10),
+			utils.FormatCounterU(row.CountUpdate, 10),
+			utils.FormatCounterU(row.CountDelete, 10),
+			row.Name)
+	}
+)
+
+// Presenter presents a TableIo struct collecting unused indexes.
+type Presenter struct {
+	*presenter.BasePresenter[tableio.Row, *tableio.TableIo]
+}
+
+// NewUnusedIndexes creates a presenter for the unused indexes using the provided model.
+func NewUnusedIndexes(model *tableio.TableIo) *Presenter {
+	bp := presenter.NewBasePresenter(
+		model,
+		"Indexes Not Read Since Startup (table_io_waits_summary_by_index_usage)",
+		defaultSortKeys,
+		defaultHasData,
+		defaultContent,
+		presenter.TableIOColumns,
+		func(r tableio.Row) string { return r.Name },
+	)
+	return &Presenter{BasePresenter: bp}
+}
+
+// Headings returns the headings for a table.
+func (p *Presenter) Headings() string {
+	return fmt.Sprintf("%10s %6s %10s|%10s %10s %10s|%s",
+		"Write Lat", "%", "Writes", "Inserts", "Updates", "Deletes", "Index Name")
+}
@@ -4,7 +4,6 @@ package userlatency
 import (
 	"database/sql"
 	"fmt"
-	"slices"
 
 	"github.com/sjmudd/ps-top/model"
 	"github.com/sjmudd/ps-top/model/userlatency"
@@ -54,9 +53,22 @@ func formatSeconds(d uint64) string {
 	return fmt.Sprintf("%ds", seconds)
 }
 
+// formatRuntime formats the given picoseconds. Times under a second are
+// only known if the running statements are available and are shown with
+// their units, longer times are shown as by formatSeconds.
+func formatRuntime(picoseconds uint64) string {
+	if picoseconds < utils.PicosecondsPerSecond {
+		return utils.FormatTime(picoseconds)
+	}
+	return formatSeconds(picoseconds / utils.PicosecondsPerSecond)
+}
+
 var (
-	defaultSort = func(rows []userlatency.Row) {
-		slices.SortFunc(rows, func(a, b userlatency.Row) int {
+	username = func(r userlatency.Row) string { return r.Username }
+
+	// sort by total time by default which is not shown as a single column
+	defaultSortKeys = []presenter.SortKey[userlatency.Row]{
+		{Heading: "", Compare: func(a, b userlatency.Row) int {
 			if a.TotalTime() > b.TotalTime() {
 				return -1
 			}
@@ -76,16 +88,23 @@ var (
 				return 1
 			}
 			return 0
-		})
+		}},
+		{Heading: "Run Time", Compare: presenter.ByValue(func(r userlatency.Row) uint64 { return r.Runtime }, username)},
+		{Heading: "Sleeping", Compare: presenter.ByValue(func(r userlatency.Row) uint64 { return r.Sleeptime }, username)},
+		{Heading: "Conn", Compare: presenter.ByValue(func(r userlatency.Row) uint64 { return r.Connections }, username)},
+		{Heading: "Actv", Compare: presenter.ByValue(func(r userlatency.Row) uint64 { return r.Active }, username)},
+		{Heading: "Hosts", Compare: presenter.ByValue(func(r userlatency.Row) uint64 { return r.Hosts }, username)},
+		{Heading: "DBs", Compare: presenter.ByValue(func(r userlatency.Row) uint64 { return r.Dbs }, username)},
+		{Heading: "User", Compare: presenter.ByName(username)},
 	}
 
 	defaultHasData = func(r userlatency.Row) bool { return r.Username != "" }
 
 	defaultContent = func(row, totals userlatency.Row) string {
 		return fmt.Sprintf("%10s %6s|%10s %6s|%4s %4s|%5s %3s|%3s %3s %3s %3s %3s|%s",
-			formatSeconds(row.Runtime),
+			formatRuntime(row.Runtime),
 			utils.FormatPct(utils.Divide(row.Runtime, totals.Runtime)),
-			formatSeconds(row.Sleeptime),
+			formatRuntime(row.Sleeptime),
 			utils.FormatPct(utils.Divide(row.Sleeptime, totals.Sleeptime)),
 			utils.FormatCounterU(row.Connections, 4),
 			utils.FormatCounterU(row.Active, 4),
@@ -98,6 +117,21 @@ var (
 			utils.FormatCounterU(row.Other, 3),
 			row.Username)
 	}
+
+	defaultColumns = []presenter.Column[userlatency.Row]{
+		{Name: "username", Value: func(r userlatency.Row) any { return r.Username }},
+		{Name: "runtime_picoseconds", Value: func(r userlatency.Row) any { return r.Runtime }},
+		{Name: "sleeptime_picoseconds", Value: func(r userlatency.Row) any { return r.Sleeptime }},
+		{Name: "connections", Value: func(r userlatency.Row) any { return r.Connections }},
+		{Name: "active", Value: func(r userlatency.Row) any { return r.Active }},
+		{Name: "hosts", Value: func(r userlatency.Row) any { return r.Hosts }},
+		{Name: "dbs", Value: func(r userlatency.Row) any { return r.Dbs }},
+		{Name: "selects", Value: func(r userlatency.Row) any { return r.Selects }},
+		{Name: "inserts", Value: func(r userlatency.Row) any { return r.Inserts }},
+		{Name: "updates", Value: func(r userlatency.Row) any { return r.Updates }},
+		{Name: "deletes", Value: func(r userlatency.Row) any { return r.Deletes }},
+		{Name: "other", Value: func(r userlatency.Row) any { return r.Other }},
+	}
 )
 
 // Presenter presents a UserLatency struct.
@@ -111,9 +145,11 @@ func NewUserLatency(cfg model.Config, db *sql.DB) *Presenter {
 	bp := presenter.NewBasePresenter(
 		ul,
 		"Activity by Username (processlist)",
-		defaultSort,
+		defaultSortKeys,
 		defaultHasData,
 		defaultContent,
+		defaultColumns,
+		username,
 	)
 	return &Presenter{BasePresenter: bp}
 }
@@ -34,3 +34,21 @@ func TestFormatSeconds(t *testing.T) {
 		}
 	}
 }
+
+// TestFormatRuntime verifies sub-second times are shown with their units.
+func TestFormatRuntime(t *testing.T) {
+	data := []struct {
+		input  uint64
+		output string
+	}{
+		{0, ""},
+		{350000000000, " 350.00 ms"},
+		{1000000000000, "1s"},
+		{70500000000000, "1m 10s"},
+	}
+	for i := range data {
+		if got := formatRuntime(data[i].input); got != data[i].output {
+			t.Errorf("formatRuntime(%v) expected: %v, got: %v", data[i].input, data[i].output, got)
+		}
+	}
+}
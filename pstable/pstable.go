@@ -9,12 +9,25 @@ import (
 	"github.com/sjmudd/ps-top/log"
 	"github.com/sjmudd/ps-top/model"
 	"github.com/sjmudd/ps-top/model/tableio"
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/presenter/activity"
 	"github.com/sjmudd/ps-top/presenter/fileinfolatency"
+	"github.com/sjmudd/ps-top/presenter/globalstatus"
+	"github.com/sjmudd/ps-top/presenter/indexusage"
+	"github.com/sjmudd/ps-top/presenter/lockwaits"
 	"github.com/sjmudd/ps-top/presenter/memoryusage"
+	"github.com/sjmudd/ps-top/presenter/metadatalocks"
 	"github.com/sjmudd/ps-top/presenter/mutexlatency"
+	"github.com/sjmudd/ps-top/presenter/replication"
+	"github.com/sjmudd/ps-top/presenter/runningstatements"
+	"github.com/sjmudd/ps-top/presenter/stageprogress"
 	"github.com/sjmudd/ps-top/presenter/stageslatency"
+	"github.com/sjmudd/ps-top/presenter/statementdigest"
+	"github.com/sjmudd/ps-top/presenter/statementhistogram"
 	"github.com/sjmudd/ps-top/presenter/tableiolatency"
 	"github.com/sjmudd/ps-top/presenter/tablelocklatency"
+	"github.com/sjmudd/ps-top/presenter/transactions"
+	"github.com/sjmudd/ps-top/presenter/unusedindexes"
 	"github.com/sjmudd/ps-top/presenter/userlatency"
 )
 
@@ -22,12 +35,30 @@ import (
 type TablerType = int
 
 const (
-	FileIoLatency TablerType = iota
+	AccountActivity TablerType = iota
+	FileIoCategory
+	FileIoEvent
+	FileIoLatency
+	GlobalStatus
+	HostActivity
+	IndexUsage
+	LockWaits
+	MemoryByOwner
 	MemoryUsage
+	MetadataLocks
 	MutexLatency
+	Replication
+	RunningStatements
+	SocketIoAddress
+	SocketIoType
+	StageProgress
 	StagesLatency
+	StatementDigest
+	StatementHistogram
 	TableIoLatency
 	TableLockLatency
+	Transactions
+	UnusedIndexes
 	UserLatency
 )
 
@@ -46,6 +77,45 @@ type Tabler interface {
 	WantRelativeStats() bool     // do we want relative stats?
 }
 
+// Sorter is implemented by tablers whose rows can be sorted on different columns
+type Sorter interface {
+	NextSortKey()        // sort on the next column
+	ReverseSort()        // toggle reversing the sort order
+	SortHeading() string // heading of the column sorted on
+	SortReversed() bool  // is the sort order reversed?
+}
+
+// Grouper is implemented by tablers whose rows can be grouped in different ways
+type Grouper interface {
+	NextGrouping() // group the rows in the next way
+}
+
+// Filterer is implemented by tablers whose rows can be filtered by name
+type Filterer interface {
+	SetFilter(pattern string) // only show rows whose names match pattern, empty shows all rows
+	Filter() string           // pattern used to filter rows, empty if not filtering
+}
+
+// Selector is implemented by tablers which allow a row to be selected
+type Selector interface {
+	MoveSelection(delta int) // move the selection down (or up if negative) by delta rows
+	SelectFirst()            // select the first row
+	SelectLast()             // select the last row
+	SelectedRow() int        // index of the selected row, -1 if none
+	SelectedName() string    // name of the selected row, empty if none
+}
+
+// Detailer is implemented by tablers which can describe a single row in detail
+type Detailer interface {
+	Detail(name string) []string // lines describing the named row, nil if not found
+}
+
+// Merger is implemented by tablers which can sort their rows together with
+// those of other tablers of the same type, e.g. collected from other servers
+type Merger interface {
+	MergeContent(tablers []any) []presenter.MergedRow // rows of all the tablers in this tabler's sort order
+}
+
 // NewTabler returns a Tabler of the requested tablerType and parameters
 func NewTabler(tablerType TablerType, cfg model.Config, db *sql.DB) Tabler {
 	var t Tabler
@@ -53,22 +123,58 @@ func NewTabler(tablerType TablerType, cfg model.Config, db *sql.DB) Tabler {
 	log.Printf("NewTabler(%v,%v,%v)\n", tablerType, cfg, db)
 
 	switch tablerType {
+	case AccountActivity:
+		t = activity.NewAccountActivity(cfg, db)
+	case FileIoCategory:
+		t = fileinfolatency.NewFileSummaryByCategory(cfg, db)
+	case FileIoEvent:
+		t = fileinfolatency.NewFileSummaryByEventName(cfg, db)
 	case FileIoLatency:
 		t = fileinfolatency.NewFileSummaryByInstance(cfg, db)
 	case TableLockLatency:
 		t = tablelocklatency.NewTableLockLatency(cfg, db)
+	case GlobalStatus:
+		t = globalstatus.NewGlobalStatus(cfg, db)
+	case HostActivity:
+		t = activity.NewHostActivity(cfg, db)
+	case IndexUsage:
+		t = indexusage.NewIndexUsage(tableio.NewIndexUsage(cfg, db))
+	case LockWaits:
+		t = lockwaits.NewLockWaits(cfg, db)
+	case MemoryByOwner:
+		t = memoryusage.NewMemoryByOwner(cfg, db)
 	case MemoryUsage:
 		t = memoryusage.NewMemoryUsage(cfg, db)
+	case MetadataLocks:
+		t = metadatalocks.NewMetadataLocks(cfg, db)
 	case MutexLatency:
 		t = mutexlatency.NewMutexLatency(cfg, db)
+	case Replication:
+		t = replication.NewReplication(cfg, db)
+	case RunningStatements:
+		t = runningstatements.NewRunningStatements(cfg, db)
+	case SocketIoAddress:
+		t = fileinfolatency.NewSocketSummaryByAddress(cfg, db)
+	case SocketIoType:
+		t = fileinfolatency.NewSocketSummaryByType(cfg, db)
+	case StageProgress:
+		t = stageprogress.NewStageProgress(cfg, db)
 	case StagesLatency:
 		t = stageslatency.NewStagesLatency(cfg, db)
+	case StatementDigest:
+		t = statementdigest.NewStatementDigest(cfg, db)
+	case StatementHistogram:
+		t = statementhistogram.NewStatementHistogram(cfg, db)
 	case TableIoLatency:
 		// Create a dedicated TableIo model for this latency presenter.
 		// If both latency and ops views are needed, create a shared model and pass
 		// to both tableiolatency.NewTableIoLatency and tableioops.NewTableIoOps directly.
 		model := tableio.NewTableIo(cfg, db)
 		t = tableiolatency.NewTableIoLatency(model)
+	case Transactions:
+		t = transactions.NewTransactions(cfg, db)
+	case UnusedIndexes:
+		t = unusedindexes.NewUnusedIndexes(tableio.NewUnusedIndexes(cfg, db))
 	case UserLatency:
 		t = userlatency.NewUserLatency(cfg, db)
 	default:
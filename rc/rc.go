@@ -6,6 +6,7 @@ package rc
 import (
 	"os"
 	"regexp"
+	"sync"
 
 	go_ini "github.com/vaughan0/go-ini" // not sure what to do with dashes in names
 
@@ -27,7 +28,8 @@ type mungeRegexp struct {
 var (
 	haveRegexps bool // Do we have any valid data? We don't check yet if it's valid.
 	regexps     []mungeRegexp
-	loaded      bool // not concurrency safe, but not needed yet!
+	loaded      bool       // have the regexps been loaded?
+	loadMu      sync.Mutex // protects loading as names may be munged concurrently
 )
 
 // modifyFilename replaces ~ with contents of HOME environment variable
@@ -109,10 +111,12 @@ func addPattern(pattern string, replace string) {
 // _[0-9]{6}$ = _YYYYMM
 func Munge(name string) string {
 	// lazy loading of regexp expressions when needed
+	loadMu.Lock()
 	if !loaded {
 		loadRegexps()
 		loaded = true
 	}
+	loadMu.Unlock()
 	if !haveRegexps {
 		return name // nothing to do so return what we were given.
 	}
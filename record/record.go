@@ -0,0 +1,166 @@
+// Package record saves the raw data collected from the database to a file
+// and reads it back so that it can be replayed later without a database.
+//
+// A recording is a gzip compressed gob stream containing a Header followed
+// by one Frame for each collection.
+package record
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sjmudd/ps-top/model/activity"
+	"github.com/sjmudd/ps-top/model/fileinfo"
+	"github.com/sjmudd/ps-top/model/globalstatus"
+	"github.com/sjmudd/ps-top/model/lockwaits"
+	"github.com/sjmudd/ps-top/model/memoryusage"
+	"github.com/sjmudd/ps-top/model/metadatalocks"
+	"github.com/sjmudd/ps-top/model/mutexlatency"
+	"github.com/sjmudd/ps-top/model/replication"
+	"github.com/sjmudd/ps-top/model/runningstatements"
+	"github.com/sjmudd/ps-top/model/stageprogress"
+	"github.com/sjmudd/ps-top/model/stageslatency"
+	"github.com/sjmudd/ps-top/model/statementdigest"
+	"github.com/sjmudd/ps-top/model/statementhistogram"
+	"github.com/sjmudd/ps-top/model/tableio"
+	"github.com/sjmudd/ps-top/model/tablelocks"
+	"github.com/sjmudd/ps-top/model/transactions"
+	"github.com/sjmudd/ps-top/model/userlatency"
+)
+
+// Version is the version of the recording format
+const Version = 1
+
+func init() {
+	// register the concrete row types which may be held in a Snapshot
+	gob.Register([]activity.Row{})
+	gob.Register([]fileinfo.Row{})
+	gob.Register([]globalstatus.Row{})
+	gob.Register([]lockwaits.Row{})
+	gob.Register([]memoryusage.Row{})
+	gob.Register([]metadatalocks.Row{})
+	gob.Register([]mutexlatency.Row{})
+	gob.Register([]replication.Row{})
+	gob.Register([]runningstatements.Row{})
+	gob.Register([]stageprogress.Row{})
+	gob.Register([]stageslatency.Row{})
+	gob.Register([]statementdigest.Row{})
+	gob.Register([]statementhistogram.Row{})
+	gob.Register([]tableio.Row{})
+	gob.Register([]tablelocks.Row{})
+	gob.Register([]transactions.Row{})
+	gob.Register([]userlatency.Row{})
+}
+
+// Recordable is implemented by tablers whose raw data can be recorded and replayed
+type Recordable interface {
+	Snapshot() (any, time.Time)
+	Replay(rows any, collected time.Time) error
+}
+
+// Header holds the information about the server being recorded
+type Header struct {
+	Version   int
+	Started   time.Time
+	Variables map[string]string // global variables of the server
+	Views     []string          // names of the views which could be selected
+}
+
+// Snapshot holds the raw data of a model from a single collection
+type Snapshot struct {
+	Collected time.Time
+	Rows      any
+}
+
+// Frame holds the snapshots of all the models collected at the same time
+type Frame struct {
+	Uptime    int                 // server uptime at the time of collection
+	Snapshots map[string]Snapshot // snapshots by model name
+}
+
+// Recorder writes frames to a recording file
+type Recorder struct {
+	file    *os.File
+	zw      *gzip.Writer
+	encoder *gob.Encoder
+}
+
+// NewRecorder creates the named file and writes the header to it
+func NewRecorder(filename string, header Header) (*Recorder, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+
+	zw := gzip.NewWriter(file)
+	r := &Recorder{
+		file:    file,
+		zw:      zw,
+		encoder: gob.NewEncoder(zw),
+	}
+
+	header.Version = Version
+	if err := r.encoder.Encode(header); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("record: failed to write header: %w", err)
+	}
+
+	return r, nil
+}
+
+// Write writes a frame to the recording. The frame is flushed to the
+// file so that a recording is usable even if ps-top does not exit cleanly.
+func (r *Recorder) Write(frame Frame) error {
+	if err := r.encoder.Encode(frame); err != nil {
+		return fmt.Errorf("record: failed to write frame: %w", err)
+	}
+	return r.zw.Flush()
+}
+
+// Close finishes the recording and closes the file
+func (r *Recorder) Close() error {
+	if err := r.zw.Close(); err != nil {
+		_ = r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+// Read reads a whole recording returning its header and frames.
+// A truncated final frame is ignored.
+func Read(in io.Reader) (Header, []Frame, error) {
+	var header Header
+
+	zr, err := gzip.NewReader(in)
+	if err != nil {
+		return header, nil, fmt.Errorf("record: %w", err)
+	}
+	defer zr.Close()
+
+	decoder := gob.NewDecoder(zr)
+	if err := decoder.Decode(&header); err != nil {
+		return header, nil, fmt.Errorf("record: failed to read header: %w", err)
+	}
+	if header.Version != Version {
+		return header, nil, fmt.Errorf("record: unsupported recording version %d, expected %d", header.Version, Version)
+	}
+
+	var frames []Frame
+	for {
+		var frame Frame
+		if err := decoder.Decode(&frame); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return header, nil, fmt.Errorf("record: failed to read frame %d: %w", len(frames)+1, err)
+		}
+		frames = append(frames, frame)
+	}
+
+	return header, frames, nil
+}
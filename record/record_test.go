@@ -0,0 +1,121 @@
+package record
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sjmudd/ps-top/model/mutexlatency"
+	"github.com/sjmudd/ps-top/model/tableio"
+)
+
+// TestRecordAndReplay checks frames are read back in order with their
+// rows restored to the original types.
+func TestRecordAndReplay(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "test.pstop")
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	r, err := NewRecorder(filename, Header{
+		Started:   started,
+		Variables: map[string]string{"hostname": "db1", "version": "8.0.36"},
+		Views:     []string{"table_io_latency", "mutex_latency"},
+	})
+	if err != nil {
+		t.Fatalf("NewRecorder() failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		collected := started.Add(time.Duration(i) * time.Second)
+		frame := Frame{
+			Uptime: 100 + i,
+			Snapshots: map[string]Snapshot{
+				"table_io": {Collected: collected, Rows: []tableio.Row{{Name: "db.t", SumTimerWait: uint64(1000 * (i + 1))}}},
+				"mutex":    {Collected: collected, Rows: []mutexlatency.Row{}},
+			},
+		}
+		if err := r.Write(frame); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	replayer, err := NewReplayer(filename, []string{"table_io", "mutex"})
+	if err != nil {
+		t.Fatalf("NewReplayer() failed: %v", err)
+	}
+	header := replayer.Header()
+	if header.Version != Version || !header.Started.Equal(started) || header.Variables["hostname"] != "db1" || len(header.Views) != 2 {
+		t.Errorf("unexpected header: %+v", header)
+	}
+
+	if position, frames := replayer.Position(); position != 0 || frames != 3 {
+		t.Errorf("expected position 0 of 3, got %d of %d", position, frames)
+	}
+	if replayer.Prev() {
+		t.Error("Prev() at the first frame should return false")
+	}
+	if !replayer.Next() || !replayer.Next() {
+		t.Fatal("Next() should move to the last frame")
+	}
+	if replayer.Next() {
+		t.Error("Next() at the last frame should return false")
+	}
+
+	frame := replayer.Frame()
+	if frame.Uptime != 102 {
+		t.Errorf("expected uptime 102, got %d", frame.Uptime)
+	}
+	rows, ok := frame.Snapshots["table_io"].Rows.([]tableio.Row)
+	if !ok || len(rows) != 1 || rows[0].Name != "db.t" || rows[0].SumTimerWait != 3000 {
+		t.Errorf("unexpected table_io rows: %#v", frame.Snapshots["table_io"].Rows)
+	}
+	if !frame.Snapshots["table_io"].Collected.Equal(started.Add(2 * time.Second)) {
+		t.Errorf("unexpected collection time: %v", frame.Snapshots["table_io"].Collected)
+	}
+	if _, ok := frame.Snapshots["mutex"].Rows.([]mutexlatency.Row); !ok {
+		t.Errorf("unexpected mutex rows: %#v", frame.Snapshots["mutex"].Rows)
+	}
+}
+
+// TestReplayNoFrames checks a recording without any data is rejected.
+func TestReplayNoFrames(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "empty.pstop")
+
+	r, err := NewRecorder(filename, Header{})
+	if err != nil {
+		t.Fatalf("NewRecorder() failed: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	if _, err := NewReplayer(filename, nil); err == nil {
+		t.Error("expected an error replaying a recording with no frames")
+	}
+}
+
+// TestReplayMissingSnapshot checks a recording without the data of one
+// of the models expected is rejected.
+func TestReplayMissingSnapshot(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "missing.pstop")
+
+	r, err := NewRecorder(filename, Header{})
+	if err != nil {
+		t.Fatalf("NewRecorder() failed: %v", err)
+	}
+	frame := Frame{Snapshots: map[string]Snapshot{"table_io": {Rows: []tableio.Row{}}}}
+	if err := r.Write(frame); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	if _, err := NewReplayer(filename, []string{"table_io"}); err != nil {
+		t.Errorf("NewReplayer() failed: %v", err)
+	}
+	if _, err := NewReplayer(filename, []string{"table_io", "mutex"}); err == nil {
+		t.Error("expected an error replaying a recording without the mutex data")
+	}
+}
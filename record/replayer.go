@@ -0,0 +1,74 @@
+package record
+
+import (
+	"fmt"
+	"os"
+)
+
+// Replayer holds a recording and the position of the current frame
+type Replayer struct {
+	header   Header
+	frames   []Frame
+	position int
+}
+
+// NewReplayer reads the named recording and positions it at the first
+// frame. The recording must be of the current version and every frame
+// must contain a snapshot of each of the named models.
+func NewReplayer(filename string, models []string) (*Replayer, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	defer file.Close()
+
+	header, frames, err := Read(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("replay: %s contains no collected data", filename)
+	}
+	for i, frame := range frames {
+		for _, name := range models {
+			if _, found := frame.Snapshots[name]; !found {
+				return nil, fmt.Errorf("replay: %s frame %d has no data for %s", filename, i+1, name)
+			}
+		}
+	}
+
+	return &Replayer{header: header, frames: frames}, nil
+}
+
+// Header returns the header of the recording
+func (r *Replayer) Header() Header {
+	return r.header
+}
+
+// Frame returns the current frame
+func (r *Replayer) Frame() Frame {
+	return r.frames[r.position]
+}
+
+// Position returns the index of the current frame and the number of frames
+func (r *Replayer) Position() (int, int) {
+	return r.position, len(r.frames)
+}
+
+// Next moves to the next frame returning false if already at the last one
+func (r *Replayer) Next() bool {
+	if r.position+1 >= len(r.frames) {
+		return false
+	}
+	r.position++
+	return true
+}
+
+// Prev moves to the previous frame returning false if already at the first one
+func (r *Replayer) Prev() bool {
+	if r.position == 0 {
+		return false
+	}
+	r.position--
+	return true
+}
@@ -1,5 +1,5 @@
 // Package setupinstruments manages the configuration of
-// performance_schema.setupinstruments.
+// performance_schema.setupinstruments and the setup_consumers it needs.
 package setupinstruments
 
 import (
@@ -9,10 +9,15 @@ import (
 )
 
 const (
-	mutexPrefix             = "wait/synch/mutex"
-	sqlMutexMonitoringMatch = "wait/synch/mutex/%"
+	innodbAlterPrefix       = "stage/innodb/alter"
+	innodbAlterMatch        = "stage/innodb/alter%"
+	mdlInstrument           = "wait/lock/metadata/sql/mdl"
+	socketPrefix            = "wait/io/socket"
+	socketMonitoringMatch   = "wait/io/socket/%"
 	sqlPrefix               = "stage/sql"
 	sqlStageMonitoringMatch = "stage/sql/%"
+	synchPrefix             = "wait/synch"
+	synchMonitoringMatch    = "wait/synch/%"
 )
 
 // List of expected errors to an UPDATE statement.  Checks are only
@@ -34,6 +39,9 @@ func updatingSetupInstrumentsMessage(filter string) string {
 	return "Updating setup_instruments configuration for: " + filter
 }
 
+// stageConsumers are the consumers which must be enabled to see the stages running now
+const stageConsumers = "'global_instrumentation','thread_instrumentation','events_stages_current'"
+
 // Row contains one row of performance_schema.setup_instruments
 type Row struct {
 	name    string
@@ -41,11 +49,18 @@ type Row struct {
 	timed   string
 }
 
+// consumer contains one row of performance_schema.setup_consumers
+type consumer struct {
+	name    string
+	enabled string
+}
+
 // SetupInstruments "object"
 type SetupInstruments struct {
 	updateTried     bool
 	updateSucceeded bool
 	rows            []Row
+	consumers       []consumer // consumers changed, with their original setting
 	db              *sql.DB
 }
 
@@ -55,10 +70,100 @@ func NewSetupInstruments(db *sql.DB) *SetupInstruments {
 	return &SetupInstruments{db: db}
 }
 
-// EnableMonitoring enables mutex and stage monitoring
+// EnableMonitoring enables synchronisation, stage, metadata lock, ALTER progress and socket I/O monitoring
 func (si *SetupInstruments) EnableMonitoring() {
-	si.EnableMutexMonitoring()
+	si.EnableSynchMonitoring()
 	si.EnableStageMonitoring()
+	si.EnableMetadataLockMonitoring()
+	si.EnableAlterMonitoring()
+	si.EnableSocketMonitoring()
+}
+
+// EnableAlterMonitoring changes settings to monitor stage/innodb/alter% and
+// enables the consumers needed to see the progress of the stages running now
+func (si *SetupInstruments) EnableAlterMonitoring() {
+	log.Println("EnableAlterMonitoring")
+
+	si.Configure(
+		setupInstrumentsFilter(innodbAlterMatch),
+		collectingSetupInstrumentsMessage(innodbAlterPrefix),
+		updatingSetupInstrumentsMessage(innodbAlterPrefix),
+	)
+	si.enableStageConsumers()
+
+	log.Println("EnableAlterMonitoring finishes")
+}
+
+// enableStageConsumers enables the stage consumers which are disabled,
+// remembering them so they can be disabled again
+func (si *SetupInstruments) enableStageConsumers() {
+	const (
+		selectSQL = "SELECT NAME, ENABLED FROM setup_consumers WHERE NAME IN (" + stageConsumers + ") AND ENABLED <> 'YES'"
+		updateSQL = "UPDATE setup_consumers SET ENABLED = 'YES' WHERE NAME = ?"
+	)
+
+	// skip if we've tried and failed to change setup_instruments
+	if si.updateTried && !si.updateSucceeded {
+		log.Println("SetupInstruments.enableStageConsumers() - Skipping configuration")
+		return
+	}
+
+	log.Println("db.query", selectSQL)
+	rows, err := si.db.Query(selectSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var disabled []consumer
+	for rows.Next() {
+		var c consumer
+		if err := rows.Scan(&c.name, &c.enabled); err != nil {
+			log.Fatal(err)
+		}
+		disabled = append(disabled, c)
+	}
+	if err := rows.Err(); err != nil {
+		log.Fatal(err)
+	}
+	_ = rows.Close()
+	log.Println("- found", len(disabled), "setup_consumers rows which need enabling")
+
+	for _, c := range disabled {
+		log.Println("- db.Exec", updateSQL, c.name)
+		if _, err := si.db.Exec(updateSQL, c.name); err != nil {
+			// not being able to see the stages running now is not fatal
+			log.Println("Unable to UPDATE setup_consumers: " + err.Error())
+			return
+		}
+		si.consumers = append(si.consumers, c)
+	}
+}
+
+// restoreConsumers restores the setup_consumers rows changed to their previous settings
+func (si *SetupInstruments) restoreConsumers() {
+	const updateSQL = "UPDATE setup_consumers SET ENABLED = ? WHERE NAME = ?"
+
+	// restore in the reverse order as consumers depend on those enabled before them
+	for i := len(si.consumers) - 1; i >= 0; i-- {
+		log.Println("db.Exec(", updateSQL, si.consumers[i].enabled, si.consumers[i].name, ")")
+		if _, err := si.db.Exec(updateSQL, si.consumers[i].enabled, si.consumers[i].name); err != nil {
+			log.Println("db.Exec error:", err)
+			return
+		}
+	}
+	log.Println(len(si.consumers), "rows changed in p_s.setup_consumers")
+}
+
+// EnableMetadataLockMonitoring changes settings to monitor wait/lock/metadata/sql/mdl
+func (si *SetupInstruments) EnableMetadataLockMonitoring() {
+	log.Println("EnableMetadataLockMonitoring")
+
+	si.Configure(
+		setupInstrumentsFilter(mdlInstrument),
+		collectingSetupInstrumentsMessage(mdlInstrument),
+		updatingSetupInstrumentsMessage(mdlInstrument),
+	)
+
+	log.Println("EnableMetadataLockMonitoring finishes")
 }
 
 // EnableStageMonitoring change settings to monitor stage/sql/%
@@ -74,17 +179,32 @@ func (si *SetupInstruments) EnableStageMonitoring() {
 	log.Println("EnableStageMonitoring finishes")
 }
 
-// EnableMutexMonitoring changes settings to monitor wait/synch/mutex/%
-func (si *SetupInstruments) EnableMutexMonitoring() {
-	log.Println("EnableMutexMonitoring")
+// EnableSynchMonitoring changes settings to monitor wait/synch/%: the
+// mutex, rwlock, prlock, sxlock and cond instruments. The original
+// settings are restored by RestoreConfiguration.
+func (si *SetupInstruments) EnableSynchMonitoring() {
+	log.Println("EnableSynchMonitoring")
+
+	si.Configure(
+		setupInstrumentsFilter(synchMonitoringMatch),
+		collectingSetupInstrumentsMessage(synchPrefix),
+		updatingSetupInstrumentsMessage(synchPrefix),
+	)
+
+	log.Println("EnableSynchMonitoring finishes")
+}
+
+// EnableSocketMonitoring changes settings to monitor wait/io/socket/%
+func (si *SetupInstruments) EnableSocketMonitoring() {
+	log.Println("EnableSocketMonitoring")
 
 	si.Configure(
-		setupInstrumentsFilter(sqlMutexMonitoringMatch),
-		collectingSetupInstrumentsMessage(mutexPrefix),
-		updatingSetupInstrumentsMessage(mutexPrefix),
+		setupInstrumentsFilter(socketMonitoringMatch),
+		collectingSetupInstrumentsMessage(socketPrefix),
+		updatingSetupInstrumentsMessage(socketPrefix),
 	)
 
-	log.Println("EnableMutexMonitoring finishes")
+	log.Println("EnableSocketMonitoring finishes")
 }
 
 // expectedError returns true if the error is in the expected list of errors
@@ -242,6 +362,8 @@ func (si *SetupInstruments) RestoreConfiguration() {
 	const updateSQL = "UPDATE setup_instruments SET enabled = ?, TIMED = ? WHERE NAME = ?"
 
 	log.Println("RestoreConfiguration()")
+	si.restoreConsumers()
+
 	// If the previous update didn't work then don't try to restore
 	if !si.updateSucceeded {
 		log.Println("Not restoring p_s.setup_instruments to original settings as initial configuration attempt failed")
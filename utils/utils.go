@@ -9,6 +9,7 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"sync"
 
 	"github.com/sjmudd/anonymiser"
 )
@@ -20,6 +21,10 @@ const (
 	// Version returns the current application version
 	Version = "1.2.1"
 
+	// PicosecondsPerSecond converts the seconds of processlist and
+	// information_schema times to the picoseconds of performance_schema
+	PicosecondsPerSecond = 1000000000000
+
 	i1024_2 = 1024 * 1024
 	i1024_3 = 1024 * 1024 * 1024
 	i1024_4 = 1024 * 1024 * 1024 * 1024
@@ -30,6 +35,9 @@ const (
 // cache the result.
 var ProgName string
 
+// anonymiserMu serialises access to the anonymiser which is not safe for concurrent use
+var anonymiserMu sync.Mutex
+
 func init() {
 	ProgName = regexp.MustCompile(`.*/`).ReplaceAllLiteralString(os.Args[0], "")
 }
@@ -215,10 +223,20 @@ func SignedDivide(a int64, b int64) float64 {
 	return float64(a) / float64(b)
 }
 
+// Anonymise returns the anonymised name using the given group. Unlike
+// calling the anonymiser directly it may be used concurrently, e.g. when
+// collecting from several servers.
+func Anonymise(group, name string) string {
+	anonymiserMu.Lock()
+	defer anonymiserMu.Unlock()
+
+	return anonymiser.Anonymise(group, name)
+}
+
 // QualifiedTableName returns the anonymised qualified table name from the columns as '<schema>.<table>'
 func QualifiedTableName(schema, table string) string {
-	schema = anonymiser.Anonymise("schema", schema)
-	table = anonymiser.Anonymise("table", table)
+	schema = Anonymise("schema", schema)
+	table = Anonymise("table", table)
 
 	var name string
 	if len(schema) > 0 {
@@ -0,0 +1,98 @@
+package view
+
+import (
+	"slices"
+	"time"
+
+	"github.com/sjmudd/ps-top/presenter"
+	"github.com/sjmudd/ps-top/pstable"
+)
+
+// tableViews are the views whose rows are named after tables so the
+// detail of a table includes its rows from each of these views.
+var tableViews = []Code{ViewLatency, ViewIO, ViewLocks}
+
+// detail holds the detail of the selected row ready to be displayed
+type detail struct {
+	description  string
+	lines        []string
+	first, last  time.Time
+	haveRelative bool
+}
+
+func (d detail) Description() string         { return d.description }
+func (d detail) Headings() string            { return presenter.DetailHeadings() }
+func (d detail) FirstCollectTime() time.Time { return d.first }
+func (d detail) LastCollectTime() time.Time  { return d.last }
+func (d detail) RowContent() []string        { return d.lines }
+func (d detail) TotalRowContent() string     { return "" }
+func (d detail) EmptyRowContent() string     { return "" }
+func (d detail) HaveRelativeStats() bool     { return d.haveRelative }
+
+// detailCodes returns the views whose rows are shown in the detail of the
+// current view's selected row, starting with the current view.
+func (m *Manager) detailCodes() []Code {
+	code := m.view.Get()
+	codes := []Code{code}
+	if code != ViewOps && !slices.Contains(tableViews, code) {
+		return codes
+	}
+	for _, c := range tableViews {
+		// the ops view shares its data with the latency view
+		if c == code || (code == ViewOps && c == ViewLatency) {
+			continue
+		}
+		if _, ok := m.tablers[c]; ok {
+			codes = append(codes, c)
+		}
+	}
+	return codes
+}
+
+// RelatedTablers returns the tablers other than the current one whose rows
+// are shown in the detail of the selected row. They need collecting while
+// the detail is shown.
+func (m *Manager) RelatedTablers() []pstable.Tabler {
+	var tablers []pstable.Tabler
+	for _, code := range m.detailCodes()[1:] {
+		tablers = append(tablers, m.tablers[code])
+	}
+	return tablers
+}
+
+// newDetail returns the detail of the selected row of the current view
+func (m *Manager) newDetail() detail {
+	current := m.CurrentTabler()
+	d := detail{
+		first:        current.FirstCollectTime(),
+		last:         current.LastCollectTime(),
+		haveRelative: current.HaveRelativeStats(),
+	}
+
+	var name string
+	if selector, ok := current.(pstable.Selector); ok {
+		name = selector.SelectedName()
+	}
+	if name == "" {
+		d.description = "Detail: no row selected, select one with the arrow keys (Enter returns)"
+		return d
+	}
+	d.description = "Detail of " + name + " (Enter returns)"
+
+	for _, code := range m.detailCodes() {
+		detailer, ok := m.tablers[code].(pstable.Detailer)
+		if !ok {
+			continue
+		}
+		lines := detailer.Detail(name)
+		if lines == nil {
+			continue
+		}
+		if len(d.lines) > 0 {
+			d.lines = append(d.lines, "")
+		}
+		d.lines = append(d.lines, lines...)
+	}
+
+	return d
+}
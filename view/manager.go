@@ -11,19 +11,27 @@ type TablerUpdater interface {
 	SetCurrentTabler(pstable.Tabler)
 }
 
+// Displayer renders data to some output device.
+// Implemented by display.Display (the screen) and display.Batch (plain text output).
+type Displayer interface {
+	Display(display.GenericData)
+	Clear()
+}
+
 // Manager manages view state, tabler selection, and display.
 // It owns the current view, maps views to tablers, and handles rendering.
 type Manager struct {
 	view    View
 	tablers map[Code]pstable.Tabler
-	display *display.Display
+	display Displayer
 	help    bool
+	detail  bool
 	updater TablerUpdater
 }
 
 // NewManager creates a Manager with the given initial view, tabler mapping, and display.
 // The updater is notified when the current tabler changes (including at initialization).
-func NewManager(v View, tablers map[Code]pstable.Tabler, d *display.Display, updater TablerUpdater) *Manager {
+func NewManager(v View, tablers map[Code]pstable.Tabler, d Displayer, updater TablerUpdater) *Manager {
 	m := &Manager{
 		view:    v,
 		tablers: tablers,
@@ -40,6 +48,16 @@ func (m *Manager) CurrentTabler() pstable.Tabler {
 	return m.tablers[m.view.Get()]
 }
 
+// Name returns the name of the current view.
+func (m *Manager) Name() string {
+	return m.view.Name()
+}
+
+// Names returns the names of all the selectable views.
+func (m *Manager) Names() []string {
+	return m.view.Names()
+}
+
 // SetNext changes to the next view and updates the current tabler.
 func (m *Manager) SetNext() {
 	m.view.SetNext()
@@ -78,10 +96,22 @@ func (m *Manager) Help() bool {
 	return m.help
 }
 
+// ToggleDetail toggles showing the detail of the selected row.
+func (m *Manager) ToggleDetail() {
+	m.detail = !m.detail
+}
+
+// Detail returns whether the detail of the selected row is currently shown.
+func (m *Manager) Detail() bool {
+	return m.detail && !m.help
+}
+
 // Display renders the current view (either help or the current tabler) to the screen.
 func (m *Manager) Display() {
 	if m.help {
 		m.display.Display(display.Help)
+	} else if m.detail {
+		m.display.Display(m.newDetail())
 	} else {
 		m.display.Display(m.CurrentTabler())
 	}
@@ -94,6 +124,7 @@ func (m *Manager) ClearDisplay() {
 
 // DisplayNext advances to the next view, clears the screen, and displays the new view.
 func (m *Manager) DisplayNext() {
+	m.detail = false
 	m.SetNext()
 	m.display.Clear()
 	m.Display()
@@ -101,6 +132,7 @@ func (m *Manager) DisplayNext() {
 
 // DisplayPrev goes to the previous view, clears the screen, and displays the new view.
 func (m *Manager) DisplayPrev() {
+	m.detail = false
 	m.SetPrev()
 	m.display.Clear()
 	m.Display()
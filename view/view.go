@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/sjmudd/ps-top/log"
+	"github.com/sjmudd/ps-top/model/lockwaits"
 	"github.com/sjmudd/ps-top/model/processlist"
 )
 
@@ -14,15 +15,33 @@ type Code int
 
 // View* constants represent different views we can see
 const (
-	ViewNone    Code = iota // view nothing (should never be set)
-	ViewLatency             // view the table latency information
-	ViewOps                 // view the table information by number of operations
-	ViewIO                  // view the file I/O information
-	ViewLocks               // view lock information
-	ViewUsers               // view user information
-	ViewMutex               // view mutex information
-	ViewStages              // view SQL stages information
-	ViewMemory              // view memory usage (5.7+)
+	ViewNone            Code = iota // view nothing (should never be set)
+	ViewLatency                     // view the table latency information
+	ViewOps                         // view the table information by number of operations
+	ViewIO                          // view the file I/O information
+	ViewLocks                       // view lock information
+	ViewUsers                       // view user information
+	ViewMutex                       // view mutex information
+	ViewStages                      // view SQL stages information
+	ViewMemory                      // view memory usage (5.7+)
+	ViewDigest                      // view statement latency by digest
+	ViewRunning                     // view the statements currently running
+	ViewLockWaits                   // view the InnoDB row lock waits
+	ViewMetadataLocks               // view the metadata locks
+	ViewReplication                 // view the replication status
+	ViewIndexUsage                  // view the table I/O of each index
+	ViewUnusedIndexes               // view the indexes not read since the server started
+	ViewAccountActivity             // view the statements run by each account
+	ViewHostActivity                // view the statements run from each host
+	ViewGlobalStatus                // view the rates of the global status counters
+	ViewTransactions                // view the open transactions
+	ViewHistogram                   // view the latency histograms of the statement digests (8.0.19+)
+	ViewStageProgress               // view the progress of the stages running now
+	ViewFileIoCategory              // view the file I/O of each category of file
+	ViewFileIoEvent                 // view the file I/O of each file event
+	ViewSocketIoType                // view the socket I/O of each type of socket
+	ViewSocketIoAddress             // view the socket I/O of the client connections from each address
+	ViewMemoryByOwner               // view the memory used by each user, host, account or thread
 )
 
 // viewDef holds the static and dynamic definition of a view
@@ -56,6 +75,24 @@ var allViewsDef = []viewDef{
 	{ViewMutex, "mutex_latency", "performance_schema.events_waits_summary_global_by_event_name", false},
 	{ViewStages, "stages_latency", "performance_schema.events_stages_summary_global_by_event_name", false},
 	{ViewMemory, "memory_usage", "performance_schema.memory_summary_global_by_event_name", false},
+	{ViewDigest, "statement_digest", "performance_schema.events_statements_summary_by_digest", false},
+	{ViewRunning, "running_statements", "performance_schema.events_statements_current", false},
+	{ViewLockWaits, "lock_waits", "performance_schema.data_lock_waits", false}, // table resolved later
+	{ViewMetadataLocks, "metadata_locks", "performance_schema.metadata_locks", false},
+	{ViewReplication, "replication", "performance_schema.replication_connection_status", false},
+	{ViewIndexUsage, "index_usage", "performance_schema.table_io_waits_summary_by_index_usage", false},
+	{ViewUnusedIndexes, "unused_indexes", "performance_schema.table_io_waits_summary_by_index_usage", false},
+	{ViewAccountActivity, "account_activity", "performance_schema.events_statements_summary_by_account_by_event_name", false},
+	{ViewHostActivity, "host_activity", "performance_schema.events_statements_summary_by_host_by_event_name", false},
+	{ViewGlobalStatus, "global_status", "performance_schema.global_status", false},
+	{ViewTransactions, "transactions", "information_schema.INNODB_TRX", false}, // used whichever table the transactions come from
+	{ViewHistogram, "statement_histogram", "performance_schema.events_statements_histogram_by_digest", false},
+	{ViewStageProgress, "stage_progress", "performance_schema.events_stages_current", false},
+	{ViewFileIoCategory, "file_io_category", "performance_schema.file_summary_by_instance", false},
+	{ViewFileIoEvent, "file_io_event", "performance_schema.file_summary_by_event_name", false},
+	{ViewSocketIoType, "socket_io_type", "performance_schema.socket_summary_by_event_name", false},
+	{ViewSocketIoAddress, "socket_io_address", "performance_schema.socket_summary_by_instance", false},
+	{ViewMemoryByOwner, "memory_by_owner", "performance_schema.memory_summary_by_user_by_event_name", false},
 }
 
 // SetupAndValidate creates a new view manager, validates table access,
@@ -64,10 +101,19 @@ var allViewsDef = []viewDef{
 func SetupAndValidate(name string, db *sql.DB) (View, error) {
 	log.Printf("view.SetupAndValidate(%q, db)", name)
 
-	// Resolve processlist table schema (depends on MySQL version)
+	// Resolve processlist and lock wait tables (depends on MySQL version)
 	defs := make([]viewDef, len(allViewsDef))
 	copy(defs, allViewsDef) // shallow copy so we can modify the table field
 	for i := range defs {
+		if defs[i].code == ViewLockWaits {
+			haveDataLocks, err := lockwaits.HaveDataLocks(db)
+			if err != nil {
+				return View{}, fmt.Errorf("SetupAndValidate: %w", err)
+			}
+			if !haveDataLocks {
+				defs[i].table = "information_schema.INNODB_LOCK_WAITS"
+			}
+		}
 		if defs[i].code == ViewUsers {
 			havePS, err := processlist.HavePerformanceSchema(db)
 			if err != nil {
@@ -99,6 +145,39 @@ func SetupAndValidate(name string, db *sql.DB) (View, error) {
 
 	log.Printf("%d of %d views are SELECTable, continuing", len(selectableViews), len(defs))
 
+	return newView(name, selectableViews)
+}
+
+// SetupWithNames creates a new view manager containing only the named
+// views and returns a View set to the requested name (or default if empty).
+// Table access is not checked so this is used when there is no database,
+// e.g. when replaying a recording.
+func SetupWithNames(name string, names []string) (View, error) {
+	log.Printf("view.SetupWithNames(%q, %v)", name, names)
+
+	wanted := make(map[string]bool, len(names))
+	for _, n := range names {
+		wanted[n] = true
+	}
+
+	selectableViews := make([]viewDef, 0, len(names))
+	for _, def := range allViewsDef {
+		if wanted[def.name] {
+			def.selectable = true
+			selectableViews = append(selectableViews, def)
+		}
+	}
+
+	if len(selectableViews) == 0 {
+		return View{}, fmt.Errorf("none of the views %v are known", names)
+	}
+
+	return newView(name, selectableViews)
+}
+
+// newView builds the manager for the given selectable views and
+// returns a View set to the requested name (or default if empty).
+func newView(name string, selectableViews []viewDef) (View, error) {
 	// Build the manager
 	manager := &viewManager{
 		views:       selectableViews,
@@ -199,6 +278,15 @@ func (v View) Name() string {
 	return ""
 }
 
+// Names returns the names of all selectable views in display order
+func (v View) Names() []string {
+	names := make([]string, 0, len(v.manager.views))
+	for _, def := range v.manager.views {
+		names = append(names, def.name)
+	}
+	return names
+}
+
 // String returns the string name (same as Name)
 func (v View) String() string {
 	return v.Name()
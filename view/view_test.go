@@ -1,7 +1,10 @@
 package view
 
 import (
+	"slices"
 	"testing"
+
+	"github.com/sjmudd/ps-top/pstable"
 )
 
 // mockViewManager creates a viewManager with the given view definitions.
@@ -190,3 +193,52 @@ func TestSetByNameValid(t *testing.T) {
 		t.Errorf("SetByName(\"table_io_ops\") expected ViewOps, got %v", v.Get())
 	}
 }
+
+// TestSetupWithNames checks only the named views are selectable and
+// that they keep the default display order.
+func TestSetupWithNames(t *testing.T) {
+	v, err := SetupWithNames("file_io_latency", []string{"memory_usage", "file_io_latency", "unknown"})
+	if err != nil {
+		t.Fatalf("SetupWithNames() failed: %v", err)
+	}
+	if v.Get() != ViewIO {
+		t.Errorf("expected ViewIO, got %v", v.Get())
+	}
+	names := v.Names()
+	if len(names) != 2 || names[0] != "file_io_latency" || names[1] != "memory_usage" {
+		t.Errorf("unexpected view names: %v", names)
+	}
+
+	if _, err := SetupWithNames("", []string{"unknown"}); err == nil {
+		t.Error("expected an error when no views are known")
+	}
+}
+
+// TestDetailCodes checks the detail of a table includes the other table views.
+func TestDetailCodes(t *testing.T) {
+	defs := []viewDef{
+		{code: ViewLatency, name: "table_io_latency", selectable: true},
+		{code: ViewOps, name: "table_io_ops", selectable: true},
+		{code: ViewIO, name: "file_io_latency", selectable: true},
+		{code: ViewLocks, name: "table_lock_latency", selectable: true},
+		{code: ViewMutex, name: "mutex_latency", selectable: true},
+	}
+	tablers := map[Code]pstable.Tabler{ViewLatency: nil, ViewOps: nil, ViewIO: nil, ViewLocks: nil, ViewMutex: nil}
+
+	tests := []struct {
+		code     Code
+		expected []Code
+	}{
+		{ViewLatency, []Code{ViewLatency, ViewIO, ViewLocks}},
+		{ViewOps, []Code{ViewOps, ViewIO, ViewLocks}},
+		{ViewLocks, []Code{ViewLocks, ViewLatency, ViewIO}},
+		{ViewMutex, []Code{ViewMutex}},
+	}
+	for _, test := range tests {
+		m := &Manager{view: mockView(mockViewManager(defs), test.code), tablers: tablers}
+		got := m.detailCodes()
+		if !slices.Equal(got, test.expected) {
+			t.Errorf("detailCodes() for %v: expected %v, got %v", test.code, test.expected, got)
+		}
+	}
+}